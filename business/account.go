@@ -1,20 +1,18 @@
 package business
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"time"
 
-	"github.com/quiver-london/go-revolut/business/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/business/request"
 )
 
 type AccountService struct {
 	accessToken string
 	sandbox     bool
-
-	err error
+	options     *request.Options
 }
 
 type AccountState string
@@ -38,9 +36,11 @@ type AccountResp struct {
 	// determines if the account is visible to other businesses on Revolut
 	Public bool `json:"public"`
 	// the instant when the account was created
-	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedAt Time `json:"updated_at"`
 	// the instant when the account was last updated
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Time `json:"created_at"`
+	// the balance as decoded, see BalanceDecimal
+	balance Decimal
 }
 
 type AccountSchema string
@@ -112,16 +112,15 @@ type BeneficiaryAddress struct {
 
 // List: This endpoint retrieves your accounts.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-accounts-get-accounts
-func (a *AccountService) List() ([]*AccountResp, error) {
-	if a.err != nil {
-		return nil, a.err
-	}
-
+func (a *AccountService) List(ctx context.Context) ([]*AccountResp, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "account.list",
 		Method:      http.MethodGet,
-		Url:         "https://b2b.revolut.com/api/1.0/accounts",
+		Url:         endpoint(nil, "accounts"),
 		AccessToken: a.accessToken,
 		Sandbox:     a.sandbox,
+		Options:     a.options,
+		Context:     ctx,
 		Body:        nil,
 	})
 	if err != nil {
@@ -142,16 +141,15 @@ func (a *AccountService) List() ([]*AccountResp, error) {
 
 // WithId: This endpoint retrieves one of your accounts by ID.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-accounts-get-account
-func (a *AccountService) WithId(id string) (*AccountResp, error) {
-	if a.err != nil {
-		return nil, a.err
-	}
-
+func (a *AccountService) WithId(ctx context.Context, id string) (*AccountResp, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "account.with_id",
 		Method:      http.MethodGet,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/accounts/%s", id),
+		Url:         endpoint(nil, "accounts", id),
 		AccessToken: a.accessToken,
 		Sandbox:     a.sandbox,
+		Options:     a.options,
+		Context:     ctx,
 		Body:        nil,
 	})
 	if err != nil {
@@ -172,15 +170,15 @@ func (a *AccountService) WithId(id string) (*AccountResp, error) {
 
 // DetailWithId: This endpoint retrieves individual account details.
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#accounts-get-account-details
-func (a *AccountService) DetailWithId(id string) ([]*AccountDetailResp, error) {
-	if a.err != nil {
-		return nil, a.err
-	}
+func (a *AccountService) DetailWithId(ctx context.Context, id string) ([]*AccountDetailResp, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "account.detail_with_id",
 		Method:      http.MethodGet,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/accounts/%s/bank-details", id),
+		Url:         endpoint(nil, "accounts", id, "bank-details"),
 		AccessToken: a.accessToken,
 		Sandbox:     a.sandbox,
+		Options:     a.options,
+		Context:     ctx,
 		Body:        nil,
 	})
 	if err != nil {
@@ -0,0 +1,100 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// AccountNameReq is a UK account to check against the name of its holder before paying it (Confirmation of Payee).
+type AccountNameReq struct {
+	// the account number of the counterparty
+	AccountNo string `json:"account_no"`
+	// the sort code of the counterparty's bank
+	SortCode string `json:"sort_code"`
+	// the name of the business counterparty, provide either this or individual_name
+	CompanyName string `json:"company_name,omitempty"`
+	// the name of the individual counterparty, provide either this or company_name
+	IndividualName *NonRevolutCounterpartyReqIndividualName `json:"individual_name,omitempty"`
+}
+
+type AccountNameResult string
+
+const (
+	// the account holder name matches the name provided
+	AccountNameResult_MATCHED AccountNameResult = "matched"
+	// the name is close to the account holder name, which is returned to be shown to the user
+	AccountNameResult_CLOSE_MATCH AccountNameResult = "close_match"
+	// the account holder name does not match the name provided
+	AccountNameResult_NOT_MATCHED AccountNameResult = "not_matched"
+	// the receiving bank could not check the name, e.g. it does not support Confirmation of Payee
+	AccountNameResult_CANNOT_BE_CHECKED AccountNameResult = "cannot_be_checked"
+)
+
+type AccountNameResp struct {
+	// the outcome of the check
+	ResultCode AccountNameResult `json:"result_code"`
+	// the reason of a close match or a failed check
+	Reason *AccountNameReason `json:"reason,omitempty"`
+	// the actual name of the business account holder, on close match
+	CompanyName string `json:"company_name,omitempty"`
+	// the actual name of the individual account holder, on close match
+	IndividualName *NonRevolutCounterpartyReqIndividualName `json:"individual_name,omitempty"`
+}
+
+type AccountNameReason struct {
+	// the type of the reason, uk_cop for Confirmation of Payee
+	Type string `json:"type"`
+	// the reason code returned by the scheme, e.g. individual_account_name_matched or account_switched
+	Code string `json:"code"`
+}
+
+// Validate checks that an account and exactly one name are set.
+func (r *AccountNameReq) Validate() error {
+	if (r.CompanyName == "") == (r.IndividualName == nil) {
+		return invalid("company_name", "or individual_name must be set, not both")
+	}
+	if r.IndividualName != nil && (r.IndividualName.FirstName == "" || r.IndividualName.LastName == "") {
+		return invalid("individual_name", "needs a first and a last name")
+	}
+	return firstErr(
+		required("account_no", r.AccountNo),
+		required("sort_code", r.SortCode),
+	)
+}
+
+// ValidateAccountName: Check the name of the holder of a UK account before adding it as a counterparty.
+// doc: https://developer.revolut.com/docs/business/validate-account-name
+func (c *CounterpartyService) ValidateAccountName(ctx context.Context, accountNameReq *AccountNameReq) (*AccountNameResp, error) {
+	if err := accountNameReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.validate_account_name",
+		Method:      http.MethodPost,
+		Url:         endpoint(nil, "account-name-validation"),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+		Body:        accountNameReq,
+		ContentType: request.ContentType_APPLICATION_JSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &AccountNameResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,44 @@
+package business
+
+import "context"
+
+// ApprovalRequired is published when a payment is created pending approval, so the application can notify the
+// approvers of the business.
+type ApprovalRequired struct {
+	Transaction *TransactionResp
+	// the request of the payment
+	Req *PaymentReq
+}
+
+func (ApprovalRequired) EventName() string { return EventName_APPROVAL_REQUIRED }
+
+// ApprovalDecided is published by WaitForApproval once an approver decided on a payment.
+type ApprovalDecided struct {
+	Transaction *TransactionResp
+	// whether the payment was approved; a rejected payment is declined, failed or reverted
+	Approved bool
+}
+
+func (ApprovalDecided) EventName() string { return EventName_APPROVAL_DECIDED }
+
+// WaitForApproval: Polls a payment pending approval, with the backoff of WaitForCompletion, until an approver
+// approves or rejects it or the context is done, and publishes ApprovalDecided. An approved payment is returned
+// in its next state, usually pending or completed; check Approved to tell a rejection from a decline.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) WaitForApproval(ctx context.Context, id string) (*TransactionResp, error) {
+	tx, err := p.poll(ctx, id, func(tx *TransactionResp) bool {
+		return tx.State != PaymentState_PENDING_APPROVAL
+	})
+	if err != nil {
+		return tx, err
+	}
+
+	p.events.Publish(ApprovalDecided{Transaction: tx, Approved: Approved(tx)})
+	return tx, nil
+}
+
+// Approved reports whether a payment passed its approval: it left the pending approval state for pending or
+// completed.
+func Approved(tx *TransactionResp) bool {
+	return tx.State == PaymentState_PENDING || tx.State == PaymentState_COMPLETE
+}
@@ -0,0 +1,33 @@
+package business
+
+import (
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// AuditEntry records a decision the library took on the caller's behalf, such as rounding an amount.
+type AuditEntry struct {
+	// the instant of the decision
+	Time time.Time
+	// what was done, e.g. "round"
+	Action string
+	// the operation it was done for, e.g. "exchange.rate"
+	Operation string
+	// the operator the operation was done on behalf of, if any
+	ActingUser *request.ActingUser
+	// action specific details
+	Details map[string]string
+}
+
+// AuditLogger receives audit entries, e.g. to persist them next to the application's own audit trail.
+type AuditLogger interface {
+	Audit(entry *AuditEntry)
+}
+
+// AuditLoggerFunc adapts an ordinary function to the AuditLogger interface.
+type AuditLoggerFunc func(entry *AuditEntry)
+
+func (f AuditLoggerFunc) Audit(entry *AuditEntry) {
+	f(entry)
+}
@@ -0,0 +1,113 @@
+package business
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// CurrencyBalance is the sum of the balances of the accounts in one currency.
+type CurrencyBalance struct {
+	Currency string `json:"currency"`
+	// the sum of the balances, added up exactly then converted to float64
+	Balance float64 `json:"balance"`
+	// the IDs of the accounts summed, in the order of the API
+	Accounts []string `json:"accounts"`
+}
+
+// BalancesByCurrency: Lists the accounts and sums their balances by currency, inactive accounts included, e.g.
+// for a treasury snapshot. The sums are computed on the exact decimals of the balances.
+// doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-accounts-get-accounts
+func (a *AccountService) BalancesByCurrency(ctx context.Context) (map[string]*CurrencyBalance, error) {
+	accounts, err := a.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return balancesByCurrency(accounts), nil
+}
+
+func balancesByCurrency(accounts []*AccountResp) map[string]*CurrencyBalance {
+	sums := map[string]*big.Rat{}
+	balances := map[string]*CurrencyBalance{}
+	for _, acc := range accounts {
+		b := balances[acc.Currency]
+		if b == nil {
+			b = &CurrencyBalance{Currency: acc.Currency}
+			balances[acc.Currency], sums[acc.Currency] = b, new(big.Rat)
+		}
+		b.Accounts = append(b.Accounts, acc.Id)
+
+		r, ok := acc.BalanceDecimal().Rat()
+		if !ok {
+			r, _ = new(big.Rat).SetString(strconv.FormatFloat(acc.Balance, 'f', -1, 64))
+		}
+		sums[acc.Currency].Add(sums[acc.Currency], r)
+	}
+	for c, b := range balances {
+		b.Balance, _ = sums[c].Float64()
+	}
+	return balances
+}
+
+// TotalBalance is the sum of the balances of every account, converted into a single currency.
+type TotalBalance struct {
+	// the converted sum of the balances that could be converted
+	Total Amount `json:"total"`
+	// the balances left out of the total because their conversion failed, by currency
+	Excluded map[string]float64 `json:"excluded,omitempty"`
+	// the conversions that failed
+	Warnings Warnings `json:"warnings,omitempty"`
+}
+
+// TotalBalance sums the balances of all accounts into the given currency at the current exchange rates.
+// Only listing the accounts is required: a currency whose rate cannot be read is reported in Excluded and
+// Warnings instead of failing the whole total.
+func (b *Client) TotalBalance(ctx context.Context, currency string) (*TotalBalance, error) {
+	account, err := b.Account()
+	if err != nil {
+		return nil, err
+	}
+	balances, err := account.BalancesByCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make([]string, 0, len(balances))
+	for c := range balances {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+
+	exchange, err := b.exchange()
+	if err != nil {
+		return nil, err
+	}
+	r := &TotalBalance{Total: Amount{Currency: currency}}
+	for _, c := range currencies {
+		sum := balances[c].Balance
+		if c == currency || sum == 0 {
+			r.Total.Amount += sum
+			continue
+		}
+
+		// the rate endpoint only prices positive amounts
+		sign := 1.0
+		if sum < 0 {
+			sign = -1
+		}
+		rate, err := exchange.Rate(ctx, &ExchangeRateReq{From: Currency(c), To: Currency(currency), Amount: sign * sum})
+		if err != nil {
+			if r.Excluded == nil {
+				r.Excluded = map[string]float64{}
+			}
+			r.Excluded[c] = sum
+			r.Warnings.Add("exchange.rate", c+" balance", err)
+			continue
+		}
+		r.Total.Amount += sign * rate.To.Amount
+	}
+	r.Total.Amount = exchange.round(ctx, "account.total_balance", r.Total.Amount, Currency(currency).Decimals())
+
+	return r, nil
+}
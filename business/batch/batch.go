@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type Status string
+
+const (
+	Status_SUCCEEDED Status = "succeeded"
+	Status_FAILED    Status = "failed"
+	Status_PENDING   Status = "pending"
+	// the payment was not created within its execution window, see Schedule
+	Status_MISSED Status = "missed"
+)
+
+// Options tunes a batch run. The client-side rate limiter of the client applies to every call on top of it,
+// and so does the retry policy of the client, each attempt of the batch retrying on its own.
+type Options struct {
+	// the number of payments executed at once, 4 when zero
+	Concurrency int
+	// the number of retries of a payment after a transient failure, 2 when zero, none when negative
+	Retries int
+	// the delay before the first retry, doubled on every following one, 500ms when zero
+	Backoff time.Duration
+	// the prefix of the request IDs given to payments without one, numbered by index.
+	// Running a batch again with the same prefix cannot pay twice. When empty, the ID generator of the
+	// client provides the IDs.
+	KeyPrefix string
+}
+
+// Result is the outcome of a single payment of the batch.
+type Result struct {
+	// the index of the payment in the batch
+	Index int
+	Req   *business.PaymentReq
+	// the transaction, nil when the payment failed without creating one
+	Transaction *business.TransactionResp
+	Status      Status
+	// the number of calls made to create the payment
+	Attempts int
+	// the last error, nil unless failed or missed
+	Err error
+	// the deadline of a scheduled payment, zero otherwise
+	Deadline time.Time
+	// the instant a scheduled payment was first submitted, zero when it never was
+	SubmittedAt time.Time
+}
+
+// Report holds the results of a batch in the order of its payments.
+type Report struct {
+	Results []*Result
+}
+
+// Succeeded returns the results of the completed payments.
+func (r *Report) Succeeded() []*Result {
+	return r.with(Status_SUCCEEDED)
+}
+
+// Failed returns the results of the payments that were declined, failed or could not be created.
+func (r *Report) Failed() []*Result {
+	return r.with(Status_FAILED)
+}
+
+// Pending returns the results of the payments created but not completed yet.
+func (r *Report) Pending() []*Result {
+	return r.with(Status_PENDING)
+}
+
+// Missed returns the results of the scheduled payments not created within their window.
+func (r *Report) Missed() []*Result {
+	return r.with(Status_MISSED)
+}
+
+func (r *Report) with(status Status) []*Result {
+	var results []*Result
+	for _, result := range r.Results {
+		if result.Status == status {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Payments executes the payment requests with bounded concurrency and returns one result per request.
+// Requests without a RequestId get one, derived from the key prefix and their index or from the client's ID
+// generator, and retries reuse it so they are deduplicated by Revolut. Only network errors, 429 and 5xx responses are retried; the context stops
+// both the pending retries and the payments not started yet.
+func Payments(ctx context.Context, client *business.Client, reqs []*business.PaymentReq, opts Options) (*Report, error) {
+	report, err := prepare(client, reqs, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	for _, result := range report.Results {
+		result := result
+		select {
+		case <-ctx.Done():
+			result.Status, result.Err = Status_FAILED, ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			pay(ctx, client, result, &opts)
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// prepare defaults the options and returns a report with a result per request, each holding a copy of its
+// request with a request ID
+func prepare(client *business.Client, reqs []*business.PaymentReq, opts *Options) (*Report, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.Retries == 0 {
+		opts.Retries = 2
+	}
+	if opts.Backoff == 0 {
+		opts.Backoff = 500 * time.Millisecond
+	}
+
+	report := &Report{Results: make([]*Result, len(reqs))}
+	for i, req := range reqs {
+		r := *req
+		if r.RequestId == "" && opts.KeyPrefix != "" {
+			r.RequestId = fmt.Sprintf("%s-%d", opts.KeyPrefix, i)
+		} else if r.RequestId == "" {
+			id, err := client.NewRequestId()
+			if err != nil {
+				return nil, err
+			}
+			r.RequestId = id
+		}
+		report.Results[i] = &Result{Index: i, Req: &r}
+	}
+	return report, nil
+}
+
+func pay(ctx context.Context, client *business.Client, result *Result, opts *Options) {
+	backoff := opts.Backoff
+	for {
+		result.Attempts++
+		var tx *business.TransactionResp
+		payments, err := client.Payment()
+		if err == nil {
+			tx, err = payments.Create(ctx, result.Req)
+		}
+		if err == nil {
+			result.Transaction, result.Status, result.Err = tx, status(tx.State), nil
+			return
+		}
+		result.Status, result.Err = Status_FAILED, err
+		if !retryable(err) || result.Attempts > opts.Retries {
+			return
+		}
+		if !result.Deadline.IsZero() && !time.Now().Add(backoff).Before(result.Deadline) {
+			result.Status, result.Err = Status_MISSED, fmt.Errorf("%w: %v", ErrMissedWindow, err)
+			return
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		backoff *= 2
+	}
+}
+
+func status(state business.PaymentState) Status {
+	switch state {
+	case business.PaymentState_COMPLETE:
+		return Status_SUCCEEDED
+	case business.PaymentState_PENDING, business.PaymentState_PENDING_APPROVAL:
+		return Status_PENDING
+	}
+	return Status_FAILED
+}
+
+func retryable(err error) bool {
+	var apiErr *request.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	// validation errors, the open circuit and the other errors of the library are not transient
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,132 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/batch"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func newServer(pending bool) *revoluttest.Server {
+	return revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts:        []*business.AccountResp{{Id: "gbp", Currency: "GBP", Balance: 100}},
+		Counterparties:  []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+		PendingPayments: pending,
+	})
+}
+
+func payment(amount float64) *business.PaymentReq {
+	return &business.PaymentReq{AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: amount, Currency: "GBP"}
+}
+
+func TestPayments(t *testing.T) {
+	s := newServer(false)
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	own := payment(30)
+	own.RequestId = "own-id"
+	reqs := []*business.PaymentReq{payment(10), payment(500), own}
+	opts := batch.Options{KeyPrefix: "run", Backoff: time.Millisecond}
+	report, err := batch.Payments(context.Background(), c, reqs, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		requestId string
+		status    batch.Status
+	}{{"run-0", batch.Status_SUCCEEDED}, {"run-1", batch.Status_FAILED}, {"own-id", batch.Status_SUCCEEDED}}
+	for i, r := range report.Results {
+		if r.Index != i || r.Req.RequestId != want[i].requestId || r.Status != want[i].status {
+			t.Errorf("result %d: got %d %s %s, want %s %s", i, r.Index, r.Req.RequestId, r.Status, want[i].requestId, want[i].status)
+		}
+	}
+	// the insufficient balance is not transient
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Attempts != 1 || failed[0].Err == nil {
+		t.Errorf("got failed results %+v, want the second payment failed once", failed)
+	}
+	if reqs[0].RequestId != "" {
+		t.Errorf("got request ID %q on the request of the caller", reqs[0].RequestId)
+	}
+	if got := s.Account("gbp").Balance; got != 60 {
+		t.Errorf("got a balance of %v, want 60", got)
+	}
+
+	// running the batch again with the same prefix pays nothing twice
+	again, err := batch.Payments(context.Background(), c, reqs, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Account("gbp").Balance; got != 60 {
+		t.Errorf("got a balance of %v after the second run, want 60", got)
+	}
+	if again.Results[0].Transaction.Id != report.Results[0].Transaction.Id {
+		t.Errorf("got transaction %s on the second run, want %s", again.Results[0].Transaction.Id, report.Results[0].Transaction.Id)
+	}
+}
+
+func TestPaymentsPending(t *testing.T) {
+	s := newServer(true)
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := batch.Payments(context.Background(), c, []*business.PaymentReq{payment(10), payment(20)}, batch.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending := report.Pending(); len(pending) != 2 {
+		t.Errorf("got %d pending payments, want 2", len(pending))
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	s := newServer(false)
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	opens := now.Add(50 * time.Millisecond)
+	payments := []*batch.ScheduledPayment{
+		{Req: payment(10), NotBefore: opens},
+		// the window closes before it opens
+		{Req: payment(10), NotBefore: now.Add(time.Hour), Deadline: now.Add(time.Minute)},
+		// the window closed already
+		{Req: payment(10), Deadline: now.Add(-time.Second)},
+		{Req: payment(10), Deadline: now.Add(time.Minute)},
+	}
+	report, err := batch.Schedule(context.Background(), c, payments, batch.ScheduleOptions{Options: batch.Options{Concurrency: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []batch.Status{batch.Status_SUCCEEDED, batch.Status_MISSED, batch.Status_MISSED, batch.Status_SUCCEEDED}
+	for i, r := range report.Results {
+		if r.Status != want[i] {
+			t.Errorf("payment %d: got %s, want %s", i, r.Status, want[i])
+		}
+		if r.Status == batch.Status_MISSED && (!errors.Is(r.Err, batch.ErrMissedWindow) || !r.SubmittedAt.IsZero()) {
+			t.Errorf("payment %d: got %v, submitted at %v, want it never submitted", i, r.Err, r.SubmittedAt)
+		}
+	}
+	if r := report.Results[0]; r.SubmittedAt.Before(opens) {
+		t.Errorf("got the payment submitted at %v, before its window opened at %v", r.SubmittedAt, opens)
+	}
+	// the earliest deadline goes first
+	if first, last := report.Results[3], report.Results[0]; !first.SubmittedAt.Before(last.SubmittedAt) {
+		t.Errorf("got the payment with a deadline submitted at %v, after the other one at %v", first.SubmittedAt, last.SubmittedAt)
+	}
+}
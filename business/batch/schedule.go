@@ -0,0 +1,147 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// ErrMissedWindow is the error of the results of payments that could not be submitted within their window.
+var ErrMissedWindow = errors.New("batch: the payment missed its execution window")
+
+// ScheduledPayment is a payment to submit within an execution window, e.g. before the SEPA cut-off of the day.
+type ScheduledPayment struct {
+	Req *business.PaymentReq
+	// the earliest instant the payment may be submitted, at once when zero
+	NotBefore time.Time
+	// the instant the payment must be submitted by, retries included; none when zero
+	Deadline time.Time
+}
+
+// ScheduleOptions tunes a scheduled run, on top of the options of a batch.
+type ScheduleOptions struct {
+	Options
+	// the most payments submitted per second, none when zero
+	Rate float64
+}
+
+// Schedule submits the payments within their windows, earliest deadline first among those whose window opened,
+// with bounded concurrency and at most opts.Rate submissions per second. A payment is only retried before its
+// deadline; one that cannot be submitted in time is not sent and gets Status_MISSED and ErrMissedWindow, see
+// Report.Missed. The results are in the order of the payments, and request IDs are given like Payments does.
+func Schedule(ctx context.Context, client *business.Client, payments []*ScheduledPayment, opts ScheduleOptions) (*Report, error) {
+	reqs := make([]*business.PaymentReq, len(payments))
+	for i, p := range payments {
+		reqs[i] = p.Req
+	}
+	report, err := prepare(client, reqs, &opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// the indexes not submitted yet, by deadline, those without one last
+	var queue []int
+	for i, p := range payments {
+		report.Results[i].Deadline = p.Deadline
+		if !p.Deadline.IsZero() && p.Deadline.Before(p.NotBefore) {
+			report.Results[i].Status, report.Results[i].Err = Status_MISSED, ErrMissedWindow
+			continue
+		}
+		queue = append(queue, i)
+	}
+	sort.SliceStable(queue, func(a, b int) bool {
+		da, db := payments[queue[a]].Deadline, payments[queue[b]].Deadline
+		return !da.IsZero() && (db.IsZero() || da.Before(db))
+	})
+
+	var interval time.Duration
+	if opts.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / opts.Rate)
+	}
+	next := time.Now()
+
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	for len(queue) > 0 {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() == nil && interval > 0 {
+			wait(ctx, next)
+			next = time.Now().Add(interval)
+		}
+
+		i, rest := pick(ctx, payments, queue, report)
+		queue = rest
+		if i < 0 {
+			if ctx.Err() == nil {
+				<-sem
+			}
+			continue
+		}
+
+		result := report.Results[i]
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			result.SubmittedAt = time.Now()
+			pay(ctx, client, result, &opts.Options)
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// pick waits until the window of a queued payment opened and returns the one with the earliest deadline, and the
+// queue without it. Payments whose deadline passed are marked missed on the way; once the context is done, every
+// queued payment fails with its error and no index is returned.
+func pick(ctx context.Context, payments []*ScheduledPayment, queue []int, report *Report) (int, []int) {
+	for {
+		if ctx.Err() != nil {
+			for _, i := range queue {
+				report.Results[i].Status, report.Results[i].Err = Status_FAILED, ctx.Err()
+			}
+			return -1, nil
+		}
+
+		now := time.Now()
+		var opens time.Time
+		rest := queue[:0]
+		chosen := -1
+		for _, i := range queue {
+			p := payments[i]
+			switch {
+			case !p.Deadline.IsZero() && !now.Before(p.Deadline):
+				report.Results[i].Status, report.Results[i].Err = Status_MISSED, ErrMissedWindow
+			case chosen < 0 && !now.Before(p.NotBefore):
+				chosen = i
+			default:
+				if opens.IsZero() || p.NotBefore.Before(opens) {
+					opens = p.NotBefore
+				}
+				rest = append(rest, i)
+			}
+		}
+		if chosen >= 0 || len(rest) == 0 {
+			return chosen, rest
+		}
+		queue = rest
+		wait(ctx, opens)
+	}
+}
+
+// wait sleeps until the instant or until the context is done
+func wait(ctx context.Context, until time.Time) {
+	t := time.NewTimer(time.Until(until))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,69 @@
+//go:build !js
+
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ListenForAuthorisationCode serves the OAuth redirect on addr (e.g. "127.0.0.1:8080") and path
+// (e.g. "/callback") and returns the authorisation code of the first redirect carrying the expected state.
+// It is meant for CLI tools and is not available on js/wasm: register http://127.0.0.1:8080/callback as the redirect URI,
+// open AuthorizationURL in a browser and wait here for the user to consent.
+func ListenForAuthorisationCode(ctx context.Context, addr, path, state string) (string, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if state != "" && q.Get("state") != state {
+			http.Error(w, "unexpected state", http.StatusBadRequest)
+			return
+		}
+
+		var res result
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("oauth: authorisation failed: %s", q.Get("error"))
+		case q.Get("code") == "":
+			res.err = errors.New("oauth: redirect carries no authorisation code")
+		default:
+			res.code = q.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorisation complete, you can close this window.")
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-results:
+		return res.code, res.err
+	}
+}
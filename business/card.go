@@ -0,0 +1,320 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type CardService struct {
+	accessToken string
+	sandbox     bool
+	options     *request.Options
+	// whether SensitiveDetails may be called, see WithSensitiveCardDetails
+	sensitive bool
+}
+
+// ErrSensitiveCardDetails is returned by SensitiveDetails on a client without WithSensitiveCardDetails.
+var ErrSensitiveCardDetails = errors.New("card: sensitive details are disabled, see WithSensitiveCardDetails")
+
+type CardState string
+
+const (
+	CardState_CREATED CardState = "created"
+	CardState_PENDING CardState = "pending"
+	CardState_ACTIVE  CardState = "active"
+	CardState_FROZEN  CardState = "frozen"
+	CardState_LOCKED  CardState = "locked"
+)
+
+type CardResp struct {
+	// the ID of the card
+	Id string `json:"id"`
+	// the last four digits of the card number
+	LastDigits string `json:"last_digits"`
+	// the expiry date of the card, MM/YYYY
+	Expiry string `json:"expiry"`
+	// the state of the card, one of created, pending, active, frozen or locked
+	State CardState `json:"state"`
+	// an optional label of the card
+	Label string `json:"label,omitempty"`
+	// whether the card is virtual
+	Virtual bool `json:"virtual"`
+	// the ID of the team member holding the card
+	HolderId string `json:"holder_id,omitempty"`
+	// the IDs of the accounts the card spends from
+	Accounts []string `json:"accounts,omitempty"`
+	// the merchant categories the card can be used at, any when empty
+	Categories []string `json:"categories,omitempty"`
+	// the limits of the card spending
+	SpendingLimits *CardSpendingLimits `json:"spending_limits,omitempty"`
+	// the instant when the card was created
+	CreatedAt Time `json:"created_at"`
+	// the instant when the card was last updated
+	UpdatedAt Time `json:"updated_at"`
+}
+
+// CardSpendingLimits are the limits of a card, a nil limit is not set.
+type CardSpendingLimits struct {
+	// the limit of a single transaction
+	Single *CardSpendingLimit `json:"single,omitempty"`
+	Day    *CardSpendingLimit `json:"day,omitempty"`
+	Week   *CardSpendingLimit `json:"week,omitempty"`
+	Month  *CardSpendingLimit `json:"month,omitempty"`
+	// the limit of the calendar quarter
+	Quarter *CardSpendingLimit `json:"quarter,omitempty"`
+	Year    *CardSpendingLimit `json:"year,omitempty"`
+	// the limit over the lifetime of the card
+	AllTime *CardSpendingLimit `json:"all_time,omitempty"`
+}
+
+type CardSpendingLimit struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// CardSensitiveDetailsResp holds the full card number and security code of a card. Never log nor store it.
+type CardSensitiveDetailsResp struct {
+	// the full card number
+	Pan string `json:"pan"`
+	// the security code
+	Cvv string `json:"cvv"`
+	// the expiry date of the card, MM/YYYY
+	Expiry string `json:"expiry"`
+	// the name printed on the card
+	CardholderName string `json:"cardholder_name,omitempty"`
+}
+
+type CardListReq struct {
+	// an optional instant to list the cards created before, the created_at of the last card of a page
+	CreatedBefore time.Time
+	// an optional number of cards to return (100 max, default is 100)
+	Limit int
+}
+
+func (r *CardListReq) params() url.Values {
+	params := url.Values{}
+	if !r.CreatedBefore.IsZero() {
+		params.Add("created_before", r.CreatedBefore.Format(time.RFC3339Nano))
+	}
+	if r.Limit != 0 {
+		params.Add("limit", strconv.Itoa(r.Limit))
+	}
+
+	return params
+}
+
+// CardUpdateReq changes the settings of a card, only the fields set are changed.
+type CardUpdateReq struct {
+	// an optional new label
+	Label string `json:"label,omitempty"`
+	// optional new merchant categories
+	Categories []string `json:"categories,omitempty"`
+	// optional new spending limits, replacing those of the card
+	SpendingLimits *CardSpendingLimits `json:"spending_limits,omitempty"`
+}
+
+// WithSensitiveCardDetails allows CardService.SensitiveDetails to retrieve full card numbers and security
+// codes. It needs the READ_SENSITIVE_CARD_DATA scope and brings the application into PCI DSS scope, so it is
+// disabled by default.
+func WithSensitiveCardDetails() Option {
+	return func(c *Client) {
+		c.sensitiveCardDetails = true
+	}
+}
+
+// List: This endpoint retrieves the cards of the business, newest first.
+// doc: https://developer.revolut.com/docs/business/get-cards
+func (c *CardService) List(ctx context.Context, cardListReq *CardListReq) ([]*CardResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "card.list",
+		Method:      http.MethodGet,
+		Url:         endpoint(cardListReq.params(), "cards"),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := []*CardResp{}
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ListAll: Iterates over every card, newest first, fetching pages with the created_before cursor.
+// doc: https://developer.revolut.com/docs/business/get-cards
+func (c *CardService) ListAll(ctx context.Context) *Pager[*CardResp] {
+	return newPager(func(cursor string) ([]*CardResp, string, error) {
+		req := &CardListReq{}
+		if cursor != "" {
+			t, err := time.Parse(time.RFC3339Nano, cursor)
+			if err != nil {
+				return nil, "", err
+			}
+			req.CreatedBefore = t
+		}
+
+		page, err := c.List(ctx, req)
+		if err != nil || len(page) == 0 {
+			return page, "", err
+		}
+		return page, page[len(page)-1].CreatedAt.Format(time.RFC3339Nano), nil
+	})
+}
+
+// WithId: This endpoint retrieves a card by ID.
+// doc: https://developer.revolut.com/docs/business/get-card
+func (c *CardService) WithId(ctx context.Context, id string) (*CardResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "card.with_id",
+		Method:      http.MethodGet,
+		Url:         endpoint(nil, "cards", id),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &CardResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// SensitiveDetails: This endpoint retrieves the full card number, security code and expiry of a card.
+// It fails with ErrSensitiveCardDetails unless the client was built with WithSensitiveCardDetails.
+// doc: https://developer.revolut.com/docs/business/get-card-sensitive-details
+func (c *CardService) SensitiveDetails(ctx context.Context, id string) (*CardSensitiveDetailsResp, error) {
+	if !c.sensitive {
+		return nil, ErrSensitiveCardDetails
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "card.sensitive_details",
+		Method:      http.MethodGet,
+		Url:         endpoint(nil, "cards", id, "sensitive-details"),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &CardSensitiveDetailsResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Freeze: This endpoint freezes a card, blocking its transactions until it is unfrozen.
+// doc: https://developer.revolut.com/docs/business/freeze-card
+func (c *CardService) Freeze(ctx context.Context, id string) error {
+	return c.post(ctx, "card.freeze", id, "freeze")
+}
+
+// Unfreeze: This endpoint unfreezes a frozen card.
+// doc: https://developer.revolut.com/docs/business/unfreeze-card
+func (c *CardService) Unfreeze(ctx context.Context, id string) error {
+	return c.post(ctx, "card.unfreeze", id, "unfreeze")
+}
+
+func (c *CardService) post(ctx context.Context, operation, id, action string) error {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   operation,
+		Method:      http.MethodPost,
+		Url:         endpoint(nil, "cards", id, action),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusNoContent {
+		return errors.New(string(resp))
+	}
+
+	return nil
+}
+
+// Update: This endpoint changes the label, merchant categories or spending limits of a card.
+// doc: https://developer.revolut.com/docs/business/update-card
+func (c *CardService) Update(ctx context.Context, id string, cardUpdateReq *CardUpdateReq) (*CardResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "card.update",
+		Method:      http.MethodPatch,
+		Url:         endpoint(nil, "cards", id),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+		Body:        cardUpdateReq,
+		ContentType: request.ContentType_APPLICATION_JSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &CardResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Terminate: This endpoint terminates a card for good, it cannot be used again.
+// doc: https://developer.revolut.com/docs/business/terminate-card
+func (c *CardService) Terminate(ctx context.Context, id string) error {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "card.terminate",
+		Method:      http.MethodDelete,
+		Url:         endpoint(nil, "cards", id),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusNoContent {
+		return errors.New(string(resp))
+	}
+
+	return nil
+}
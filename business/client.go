@@ -0,0 +1,432 @@
+package business
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// endpoints lists the base urls of every host the business API talks to
+var endpoints = []string{apiUrl, consentUrl}
+
+// Client is the entry point of the Business API. It is immutable once NewClient returns and safe for concurrent use:
+// options only run during construction, and the state shared by its copies and services, such as the token manager,
+// the event bus or the rate limiter, synchronises itself.
+//
+// Every accessor, e.g. Account or Payment, returns a new service holding a valid access token, or the error of
+// obtaining one. Services are never modified either, so they may be shared between goroutines, but they should be
+// short lived: build one per call or per batch of calls rather than keeping one past the lifetime of its token.
+type Client struct {
+	clientId     string
+	sandbox      bool
+	privateKey   crypto.Signer
+	issuer       string
+	refreshToken string
+
+	oa            *OAuthService
+	tokenStore    TokenStore
+	consentExpiry time.Time
+	tokens        *TokenManager
+	events        *EventBus
+	options       *request.Options
+	rounding      *Rounding
+	rateCache     *RateCache
+	onReauth      func(Reauthorisation)
+	onApproval    func(ApprovalRequired)
+	// whether card sensitive details may be retrieved
+	sensitiveCardDetails bool
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithLogger registers a logger called after every request made by the client.
+func WithLogger(logger request.Logger) Option {
+	return func(c *Client) {
+		c.options.Logger = logger
+	}
+}
+
+// WithTracerProvider records every request made by the client as an OpenTelemetry span.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.options.TracerProvider = tp
+	}
+}
+
+// WithMetrics reports request counts, errors and latencies of every endpoint to the collector.
+func WithMetrics(m request.MetricsCollector) Option {
+	return func(c *Client) {
+		c.options.Metrics = m
+	}
+}
+
+// WithRateLimit throttles the client to rps requests per second with bursts of up to burst requests,
+// shared by all services of the client.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.options.Limiter = request.NewTokenBucket(rps, burst)
+	}
+}
+
+// WithRateLimiter throttles the client with a custom limiter, e.g. one shared by several clients.
+func WithRateLimiter(l request.RateLimiter) Option {
+	return func(c *Client) {
+		c.options.Limiter = l
+	}
+}
+
+// WithTokenStore keeps the client's tokens in the given store instead of in memory,
+// so several instances can share refreshed tokens.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// WithRoundingMode sets how amounts are rounded when the library has to round, half up by default.
+func WithRoundingMode(mode RoundingMode) Option {
+	return func(c *Client) {
+		c.rounding.Mode = mode
+	}
+}
+
+// WithAuditLogger records decisions taken by the library, such as roundings, in the audit logger.
+func WithAuditLogger(audit AuditLogger) Option {
+	return func(c *Client) {
+		c.rounding.Audit = audit
+	}
+}
+
+// WithEventBus publishes the client's events, such as token lifecycle events, on the given bus.
+func WithEventBus(bus *EventBus) Option {
+	return func(c *Client) {
+		c.events = bus
+	}
+}
+
+// WithConsentExpiry tells the client when the user's consent expires, so ConsentExpiring events
+// can be published ahead of it. It only applies while the token store holds no token.
+func WithConsentExpiry(t time.Time) Option {
+	return func(c *Client) {
+		c.consentExpiry = t
+	}
+}
+
+// WithConsentGrantedAt tells the client when the user consented, the consent expiring ConsentLifetime later.
+func WithConsentGrantedAt(t time.Time) Option {
+	return WithConsentExpiry(t.Add(ConsentLifetime))
+}
+
+// WithOnReauthorisationRequired calls fn once the user's consent is about to expire, a week ahead and once per
+// expiry, and whenever Revolut rejects the refresh token, so the application can prompt the user to consent again
+// before calls start failing. The consent expiry must be known, see WithConsentExpiry. fn runs on the refreshing
+// goroutine and must not block.
+func WithOnReauthorisationRequired(fn func(Reauthorisation)) Option {
+	return func(c *Client) {
+		c.onReauth = fn
+	}
+}
+
+// WithOnApprovalRequired calls fn whenever a payment of the client is created pending approval, e.g. to notify
+// the approvers of the business. fn runs on the paying goroutine and must not block.
+func WithOnApprovalRequired(fn func(ApprovalRequired)) Option {
+	return func(c *Client) {
+		c.onApproval = fn
+	}
+}
+
+// WithHTTPClient sends the client's requests through the given HTTP client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.options.HTTPClient = hc
+	}
+}
+
+// WithMiddleware wraps the HTTP client of the client's requests in the middlewares, after those of earlier
+// WithMiddleware options, the first one outermost. See request.Middleware.
+func WithMiddleware(middlewares ...request.Middleware) Option {
+	return func(c *Client) {
+		c.options.Middleware = append(c.options.Middleware, middlewares...)
+	}
+}
+
+// WithCircuitBreaker fails the client's requests fast while the breaker is open, see request.CircuitBreaker.
+// It wraps the middlewares of later WithMiddleware options, so their faults count as failures.
+func WithCircuitBreaker(b *request.CircuitBreaker) Option {
+	return WithMiddleware(b.Middleware())
+}
+
+// WithConnectionPool gives the client an HTTP client of its own with a tuned connection pool.
+func WithConnectionPool(pool request.PoolConfig) Option {
+	return func(c *Client) {
+		c.options.HTTPClient = request.NewHTTPClient(pool)
+	}
+}
+
+// WithTimeout bounds every request whose context has no deadline, retries included, instead of
+// request.DefaultTimeout.
+// A negative timeout lets requests without a deadline hang for as long as the server does.
+// Connection and TLS handshake timeouts are set on the HTTP client, see request.PoolConfig.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.options.Timeout = timeout
+	}
+}
+
+// WithUserAgent sends userAgent as the User-Agent of every request instead of request.DefaultUserAgent,
+// e.g. "acme-payouts/1.4 go-revolut".
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.options.UserAgent = userAgent
+	}
+}
+
+// WithHeader sends the extra header with every request of the client. Per call headers are attached to
+// the context with request.WithHeader.
+func WithHeader(name, value string) Option {
+	return func(c *Client) {
+		if c.options.Header == nil {
+			c.options.Header = http.Header{}
+		}
+		c.options.Header.Add(name, value)
+	}
+}
+
+// WithIDGenerator makes the client create its request IDs with g, e.g. a PrefixGenerator embedding the tenant.
+func WithIDGenerator(g request.IDGenerator) Option {
+	return func(c *Client) {
+		c.options.IDGenerator = g
+	}
+}
+
+// WithRetry retries requests failing with a network error, a 429 or a 5xx response. Payments, transfers and
+// exchanges are only retried with the request_id set by the caller, unless the policy allows generated ones.
+func WithRetry(policy request.RetryPolicy) Option {
+	return func(c *Client) {
+		c.options.Retry = &policy
+	}
+}
+
+// WithDryRun turns every request changing state, such as payments, exchanges and counterparty deletions, into a no-op
+// returning a *request.DryRunError with the request that would have been sent. Reads still reach the API, so an
+// integration can be exercised against production without moving money.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.options.DryRun = true
+	}
+}
+
+func NewClient(clientId, refreshToken string, privateKey crypto.Signer, issuer string, sandbox bool, opts ...Option) (*Client, error) {
+	if sandbox {
+		if err := request.ValidateSandbox(endpoints...); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &Client{
+		clientId:     clientId,
+		sandbox:      sandbox,
+		privateKey:   privateKey,
+		issuer:       issuer,
+		refreshToken: refreshToken,
+		tokenStore:   NewMemoryTokenStore(),
+		events:       NewEventBus(),
+		options:      &request.Options{RateLimits: &request.RateLimitTracker{}},
+		rounding:     &Rounding{Mode: RoundingMode_HALF_UP},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.oa = &OAuthService{
+		clientId:   clientId,
+		privateKey: privateKey,
+		issuer:     issuer,
+		sandbox:    sandbox,
+		options:    c.options,
+	}
+
+	if !c.consentExpiry.IsZero() {
+		if err := seedConsentExpiry(context.Background(), c.tokenStore, c.consentExpiry); err != nil {
+			return nil, err
+		}
+	}
+
+	c.tokens = NewTokenManager(c.oa, c.tokenStore, refreshToken)
+	c.tokens.events = c.events
+	c.tokens.onReauth = c.onReauth
+	if c.onApproval != nil {
+		c.events.Subscribe(EventName_APPROVAL_REQUIRED, func(e Event) {
+			c.onApproval(e.(ApprovalRequired))
+		})
+	}
+
+	if _, err := c.tokens.AccessToken(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ConsentExpiry returns the instant the user's consent expires, zero when unknown.
+func (b *Client) ConsentExpiry() (time.Time, error) {
+	return b.tokens.ConsentExpiry(context.Background())
+}
+
+// Revoke revokes the client's refresh token, e.g. when off-boarding a customer, and empties its token store.
+// Every later call fails until the user consents again and a new client is built with the new refresh token.
+func (b *Client) Revoke(ctx context.Context) error {
+	return b.tokens.Revoke(ctx)
+}
+
+// Events returns the bus the client publishes its events on.
+func (b *Client) Events() *EventBus {
+	return b.events
+}
+
+// NewRequestId returns a new identifier from the ID generator of the client.
+func (b *Client) NewRequestId() (string, error) {
+	return b.options.NewId()
+}
+
+// RateLimit returns the latest request budget Revolut reported, nil until a response carried one.
+func (b *Client) RateLimit() *request.RateLimit {
+	return b.options.RateLimits.Last()
+}
+
+// Rounding returns a copy of the rounding policy of the client. Changing it does not affect the client.
+func (b *Client) Rounding() *Rounding {
+	r := *b.rounding
+	return &r
+}
+
+func (b *Client) Account() (AccountReader, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &AccountService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
+
+func (b *Client) Counterparty() (CounterpartyManager, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &CounterpartyService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
+
+func (b *Client) Transfer() (Transferrer, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &TransferService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
+
+func (b *Client) Payment() (Payer, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+		events:      b.events,
+	}, nil
+}
+
+func (b *Client) PaymentDraft() (PaymentDrafter, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentDraftService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
+
+func (b *Client) Exchange() (Exchanger, error) {
+	e, err := b.exchange()
+	if err != nil {
+		return nil, err
+	}
+	if b.rateCache != nil {
+		return b.rateCache.Wrap(e), nil
+	}
+	return e, nil
+}
+
+func (b *Client) exchange() (*ExchangeService, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+		rounding:    b.rounding,
+	}, nil
+}
+
+// Sandbox returns the simulation endpoints, it fails on a production client.
+func (b *Client) Sandbox() (Simulator, error) {
+	if !b.sandbox {
+		return nil, errNotSandbox
+	}
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &SandboxService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
+
+func (b *Client) Card() (CardManager, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &CardService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+		sensitive:   b.sensitiveCardDetails,
+	}, nil
+}
+
+func (b *Client) Webhook() (WebhookManager, error) {
+	accessToken, err := b.tokens.AccessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookService{
+		accessToken: accessToken,
+		sandbox:     b.sandbox,
+		options:     b.options,
+	}, nil
+}
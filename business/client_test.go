@@ -0,0 +1,132 @@
+package business_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+// TestClientConcurrentUse shares one client between goroutines calling every kind of service while the access
+// token is refreshed on every call; run it with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts: []*business.AccountResp{
+			{Id: "gbp", Currency: "GBP", Balance: 1000000},
+			{Id: "eur", Currency: "EUR", Balance: 1000000},
+		},
+		Counterparties: []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+		Rates:          map[string]float64{"GBP/EUR": 1.17},
+		ExchangeFee:    0.005,
+		// shorter than the refresh leeway, every access token is refreshed
+		TokenTTL: time.Second,
+	})
+	defer s.Close()
+
+	var refreshed int64
+	bus := business.NewEventBus()
+	c, err := s.Client(
+		business.WithEventBus(bus),
+		business.WithRateCache(business.NewRateCache(time.Minute, 10)),
+		business.WithRoundingMode(business.RoundingMode_HALF_EVEN),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	calls := []func(i int) error{
+		func(i int) error {
+			a, err := c.Account()
+			if err != nil {
+				return err
+			}
+			_, err = a.List(ctx)
+			return err
+		},
+		func(i int) error {
+			e, err := c.Exchange()
+			if err != nil {
+				return err
+			}
+			_, err = e.Rate(ctx, &business.ExchangeRateReq{From: "GBP", To: "EUR", Amount: 100})
+			return err
+		},
+		func(i int) error {
+			e, err := c.Exchange()
+			if err != nil {
+				return err
+			}
+			_, err = e.Exchange(ctx, &business.ExchangeReq{
+				RequestId: fmt.Sprintf("exchange-%d", i),
+				From:      business.ExchangeAmount{AccountId: "gbp", Currency: "GBP", Amount: 10},
+				To:        business.ExchangeAmount{AccountId: "eur", Currency: "EUR"},
+			})
+			return err
+		},
+		func(i int) error {
+			p, err := c.Payment()
+			if err != nil {
+				return err
+			}
+			_, err = p.Create(ctx, &business.PaymentReq{
+				RequestId: fmt.Sprintf("payment-%d", i),
+				AccountId: "gbp",
+				Receiver:  business.PaymentReceiver{CounterpartyId: "supplier"},
+				Amount:    1,
+				Currency:  "GBP",
+			})
+			return err
+		},
+		func(i int) error {
+			_, err := c.TotalBalance(ctx, "GBP")
+			return err
+		},
+		func(i int) error {
+			// the policy returned is a copy, changing it races with nothing
+			c.Rounding().Mode = business.RoundingMode_TRUNCATE
+			c.Events().Subscribe(business.EventName_TOKEN_REFRESHED, func(business.Event) {
+				atomic.AddInt64(&refreshed, 1)
+			})
+			return nil
+		},
+	}
+
+	const goroutines = 8
+	const rounds = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*rounds*len(calls))
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				for _, call := range calls {
+					if err := call(g*rounds + r); err != nil {
+						errs <- err
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := c.Rounding().Mode; got != business.RoundingMode_HALF_EVEN {
+		t.Errorf("rounding mode changed to %s through a copy", got)
+	}
+	if n := s.Calls("POST /pay"); n != goroutines*rounds {
+		t.Errorf("got %d payments, want %d", n, goroutines*rounds)
+	}
+	if atomic.LoadInt64(&refreshed) == 0 {
+		t.Error("no token refreshed")
+	}
+}
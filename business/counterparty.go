@@ -1,20 +1,21 @@
 package business
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
-	"github.com/quiver-london/go-revolut/business/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/business/request"
 )
 
 type CounterpartyService struct {
 	accessToken string
 	sandbox     bool
-
-	err error
+	options     *request.Options
 }
 
 type CounterpartyProfileType string
@@ -52,11 +53,15 @@ type NonRevolutCounterpartyReq struct {
 	// the currency of a counterparty's account
 	Currency string `json:"currency"`
 	// bank account number
-	AccountNo string `json:"account_no"`
+	AccountNo string `json:"account_no,omitempty"`
 	// sort code
-	SortCode string `json:"sort_code"`
+	SortCode string `json:"sort_code,omitempty"`
 	// routing transit number
-	RoutingNumber string `json:"routing_number"`
+	RoutingNumber string `json:"routing_number,omitempty"`
+	// IBAN
+	Iban string `json:"iban,omitempty"`
+	// BIC
+	Bic string `json:"bic,omitempty"`
 	// an optional email address of the beneficiary
 	Email string `json:"email,omitempty"`
 	// an optional phone number of the beneficiary
@@ -107,11 +112,13 @@ type CounterpartyResp struct {
 	// the state of the counterparty, one of created, deleted
 	State CounterpartyState `json:"state"`
 	// the instant when the counterparty was created
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Time `json:"created_at"`
 	// the instant when the counterparty was last updated
-	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedAt Time `json:"updated_at"`
 	// the list of public accounts of this counterparty
 	Accounts []CounterpartyRespAccount `json:"accounts"`
+	// the list of cards of this counterparty
+	Cards []CounterpartyRespCard `json:"cards,omitempty"`
 }
 
 type CounterpartyRecipientCharges string
@@ -145,18 +152,36 @@ type CounterpartyRespAccount struct {
 	RecipientCharges CounterpartyRecipientCharges `json:"recipient_charges"`
 }
 
+type CounterpartyRespCard struct {
+	// the ID of a counterparty's card, used as receiver.card_id of a payment
+	Id string `json:"id"`
+	// the name of the cardholder
+	Name string `json:"name"`
+	// the last four digits of the card number
+	LastDigits string `json:"last_digits"`
+	// the card scheme, visa or mastercard
+	Scheme string `json:"scheme"`
+	// the country of the card issuer
+	Country string `json:"country"`
+	// the currency of the card
+	Currency string `json:"currency"`
+}
+
 // AddRevolut: You can create a counterparty for an existing Revolut user.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-add-revolut-counterparty
-func (c *CounterpartyService) AddRevolut(revolutCounterparty *RevolutCounterpartyReq) (*CounterpartyResp, error) {
-	if c.err != nil {
-		return nil, c.err
+func (c *CounterpartyService) AddRevolut(ctx context.Context, revolutCounterparty *RevolutCounterpartyReq) (*CounterpartyResp, error) {
+	if err := revolutCounterparty.Validate(); err != nil {
+		return nil, err
 	}
 
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.add_revolut",
 		Method:      http.MethodPost,
-		Url:         "https://b2b.revolut.com/api/1.0/counterparty",
+		Url:         endpoint(nil, "counterparty"),
 		AccessToken: c.accessToken,
 		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
 		Body:        revolutCounterparty,
 		ContentType: request.ContentType_APPLICATION_JSON,
 	})
@@ -177,16 +202,19 @@ func (c *CounterpartyService) AddRevolut(revolutCounterparty *RevolutCounterpart
 
 // AddNonRevolut: You can create a counterparty for an non-Revolut bank account.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-add-non-revolut-counterparty
-func (c *CounterpartyService) AddNonRevolut(nonRevolutCounterparty *NonRevolutCounterpartyReq) (*CounterpartyResp, error) {
-	if c.err != nil {
-		return nil, c.err
+func (c *CounterpartyService) AddNonRevolut(ctx context.Context, nonRevolutCounterparty *NonRevolutCounterpartyReq) (*CounterpartyResp, error) {
+	if err := nonRevolutCounterparty.Validate(); err != nil {
+		return nil, err
 	}
 
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.add_non_revolut",
 		Method:      http.MethodPost,
-		Url:         "https://b2b.revolut.com/api/1.0/counterparty",
+		Url:         endpoint(nil, "counterparty"),
 		AccessToken: c.accessToken,
 		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
 		ContentType: request.ContentType_APPLICATION_JSON,
 		Body:        nonRevolutCounterparty,
 	})
@@ -209,42 +237,39 @@ func (c *CounterpartyService) AddNonRevolut(nonRevolutCounterparty *NonRevolutCo
 // Delete: This endpoint deletes a counterparty with the given ID.
 // Once a counterparty is deleted no payments can be made to it.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-delete-counterparty
-func (c *CounterpartyService) Delete(id string) error {
-	if c.err != nil {
-		return c.err
-	}
-
+func (c *CounterpartyService) Delete(ctx context.Context, id string) error {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.delete",
 		Method:      http.MethodDelete,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/counterparty/%s", id),
+		Url:         endpoint(nil, "counterparty", id),
 		AccessToken: c.accessToken,
 		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
 		Body:        nil,
 	})
+	if err != nil {
+		return err
+	}
 
 	if statusCode != http.StatusNoContent {
 		return errors.New(string(resp))
 	}
 
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
 // WithId: This endpoint retrieves a counterparty by ID.
 // doc https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-get-counterparty
-func (c *CounterpartyService) WithId(id string) (*CounterpartyResp, error) {
-	if c.err != nil {
-		return nil, c.err
-	}
-
+func (c *CounterpartyService) WithId(ctx context.Context, id string) (*CounterpartyResp, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.with_id",
 		Method:      http.MethodGet,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/counterparty/%s", id),
+		Url:         endpoint(nil, "counterparty", id),
 		AccessToken: c.accessToken,
 		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
 		Body:        nil,
 	})
 	if err != nil {
@@ -265,17 +290,67 @@ func (c *CounterpartyService) WithId(id string) (*CounterpartyResp, error) {
 
 // List: This endpoint retrieves all your counterparties.
 // doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-get-counterparties
-func (c *CounterpartyService) List() ([]*CounterpartyResp, error) {
-	if c.err != nil {
-		return nil, c.err
+func (c *CounterpartyService) List(ctx context.Context) ([]*CounterpartyResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.list",
+		Method:      http.MethodGet,
+		Url:         endpoint(nil, "counterparties"),
+		AccessToken: c.accessToken,
+		Sandbox:     c.sandbox,
+		Options:     c.options,
+		Context:     ctx,
+		Body:        nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := []*CounterpartyResp{}
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// maxCounterpartyCount is the largest page of counterparties the API returns
+const maxCounterpartyCount = 1000
+
+// ListAll: Iterates over every counterparty, newest first, fetching pages with the created_before cursor.
+// doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-counterparties-get-counterparties
+func (c *CounterpartyService) ListAll(ctx context.Context) *Pager[*CounterpartyResp] {
+	return newPager(func(cursor string) ([]*CounterpartyResp, string, error) {
+		page, err := c.listPage(ctx, cursor, maxCounterpartyCount)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(page) < maxCounterpartyCount {
+			return page, "", nil
+		}
+
+		return page, page[len(page)-1].CreatedAt.Format(time.RFC3339Nano), nil
+	})
+}
+
+func (c *CounterpartyService) listPage(ctx context.Context, createdBefore string, limit int) ([]*CounterpartyResp, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	if createdBefore != "" {
+		params.Set("created_before", createdBefore)
 	}
 
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "counterparty.list",
 		Method:      http.MethodGet,
-		Url:         "https://b2b.revolut.com/api/1.0/counterparties",
+		Url:         endpoint(params, "counterparties"),
 		AccessToken: c.accessToken,
 		Sandbox:     c.sandbox,
-		Body:        nil,
+		Options:     c.options,
+		Context:     ctx,
 	})
 	if err != nil {
 		return nil, err
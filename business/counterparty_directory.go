@@ -0,0 +1,146 @@
+package business
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CounterpartyDirectory caches the counterparty list of a client and resolves counterparties by their
+// identifiers, so payments to known payees don't each list the counterparties again. A lookup missing
+// the cache lists the counterparties once more, in case the payee was just added. Like FindByIban,
+// lookups resolve duplicates to their canonical counterparty. It is safe for concurrent use.
+type CounterpartyDirectory struct {
+	client *Client
+	ttl    time.Duration
+
+	mu             sync.Mutex
+	counterparties []*CounterpartyResp
+	fetched        time.Time
+}
+
+// NewCounterpartyDirectory returns a directory over the counterparties of the client, listing them again
+// once the cached list is older than ttl. A ttl of zero or less keeps the list until a miss or Refresh.
+func NewCounterpartyDirectory(client *Client, ttl time.Duration) *CounterpartyDirectory {
+	return &CounterpartyDirectory{client: client, ttl: ttl}
+}
+
+// ByIban returns the counterparty holding an account with the IBAN, nil when there is none.
+func (d *CounterpartyDirectory) ByIban(ctx context.Context, iban string) (*CounterpartyResp, error) {
+	iban = normaliseIban(iban)
+	if iban == "" {
+		return nil, nil
+	}
+	return d.lookup(ctx, func(a CounterpartyRespAccount) bool {
+		return normaliseIban(a.Iban) == iban
+	})
+}
+
+// ByAccountNo returns the counterparty holding a UK account with the account number and sort code, nil
+// when there is none.
+func (d *CounterpartyDirectory) ByAccountNo(ctx context.Context, accountNo, sortCode string) (*CounterpartyResp, error) {
+	accountNo, sortCode = normaliseDigits(accountNo), normaliseDigits(sortCode)
+	if accountNo == "" {
+		return nil, nil
+	}
+	return d.lookup(ctx, func(a CounterpartyRespAccount) bool {
+		return normaliseDigits(a.AccountNo) == accountNo && normaliseDigits(a.SortCode) == sortCode
+	})
+}
+
+// ByPhone returns the Revolut counterparty with the phone number, nil when there is none.
+func (d *CounterpartyDirectory) ByPhone(ctx context.Context, phone string) (*CounterpartyResp, error) {
+	phone = normalisePhone(phone)
+	if phone == "" {
+		return nil, nil
+	}
+	return d.find(ctx, func(counterparties []*CounterpartyResp) *CounterpartyResp {
+		for _, cp := range counterparties {
+			if normalisePhone(cp.Phone) == phone {
+				return cp
+			}
+		}
+		return nil
+	})
+}
+
+// ById returns the counterparty with the ID, nil when there is none.
+func (d *CounterpartyDirectory) ById(ctx context.Context, id string) (*CounterpartyResp, error) {
+	return d.find(ctx, func(counterparties []*CounterpartyResp) *CounterpartyResp {
+		for _, cp := range counterparties {
+			if cp.Id == id {
+				return cp
+			}
+		}
+		return nil
+	})
+}
+
+// Refresh lists the counterparties again.
+func (d *CounterpartyDirectory) Refresh(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.refresh(ctx)
+}
+
+// Invalidate drops the cached list, the next lookup lists the counterparties again.
+func (d *CounterpartyDirectory) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.counterparties, d.fetched = nil, time.Time{}
+}
+
+func (d *CounterpartyDirectory) lookup(ctx context.Context, match func(a CounterpartyRespAccount) bool) (*CounterpartyResp, error) {
+	return d.find(ctx, func(counterparties []*CounterpartyResp) *CounterpartyResp {
+		return findCanonical(counterparties, match)
+	})
+}
+
+// find runs the search on the cached list, refreshing it first when stale and once more on a miss.
+func (d *CounterpartyDirectory) find(ctx context.Context, search func([]*CounterpartyResp) *CounterpartyResp) (*CounterpartyResp, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fresh := false
+	if d.fetched.IsZero() || (d.ttl > 0 && time.Since(d.fetched) >= d.ttl) {
+		if err := d.refresh(ctx); err != nil {
+			return nil, err
+		}
+		fresh = true
+	}
+	if cp := search(d.counterparties); cp != nil || fresh {
+		return cp, nil
+	}
+
+	if err := d.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return search(d.counterparties), nil
+}
+
+func (d *CounterpartyDirectory) refresh(ctx context.Context) error {
+	counterparties, err := d.client.Counterparty()
+	if err != nil {
+		return err
+	}
+	list, err := counterparties.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.counterparties, d.fetched = list, time.Now()
+	return nil
+}
+
+// normaliseDigits drops the spaces and dashes of account numbers and sort codes
+func normaliseDigits(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// normalisePhone drops the separators of a phone number, keeping the leading +
+func normalisePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(phone)
+}
@@ -0,0 +1,183 @@
+package business
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+type DuplicateReason string
+
+const (
+	DuplicateReason_IBAN  DuplicateReason = "iban"
+	DuplicateReason_EMAIL DuplicateReason = "email"
+)
+
+type CounterpartyDuplicate struct {
+	// what the counterparties have in common, iban or email
+	Reason DuplicateReason
+	// the shared, normalised IBAN or email
+	Key string
+	// the suggested canonical counterparty: the oldest active one
+	Canonical *CounterpartyResp
+	// the other counterparties sharing the key, suggested to be merged into the canonical one
+	Duplicates []*CounterpartyResp
+}
+
+// FindDuplicateCounterparties flags counterparties that probably are the same payee:
+// different counterparties holding an account with the same IBAN or the same email address.
+func FindDuplicateCounterparties(counterparties []*CounterpartyResp) []*CounterpartyDuplicate {
+	groups := map[DuplicateReason]map[string][]*CounterpartyResp{
+		DuplicateReason_IBAN:  {},
+		DuplicateReason_EMAIL: {},
+	}
+	add := func(reason DuplicateReason, key string, c *CounterpartyResp) {
+		for _, existing := range groups[reason][key] {
+			if existing.Id == c.Id {
+				return
+			}
+		}
+		groups[reason][key] = append(groups[reason][key], c)
+	}
+
+	for _, c := range counterparties {
+		for _, a := range c.Accounts {
+			if iban := normaliseIban(a.Iban); iban != "" {
+				add(DuplicateReason_IBAN, iban, c)
+			}
+			if email := normaliseEmail(a.Email); email != "" {
+				add(DuplicateReason_EMAIL, email, c)
+			}
+		}
+	}
+
+	var r []*CounterpartyDuplicate
+	for _, reason := range []DuplicateReason{DuplicateReason_IBAN, DuplicateReason_EMAIL} {
+		keys := make([]string, 0, len(groups[reason]))
+		for key := range groups[reason] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			group := groups[reason][key]
+			if len(group) < 2 {
+				continue
+			}
+			sortCanonicalFirst(group)
+			r = append(r, &CounterpartyDuplicate{
+				Reason:     reason,
+				Key:        key,
+				Canonical:  group[0],
+				Duplicates: group[1:],
+			})
+		}
+	}
+
+	return r
+}
+
+// CounterpartyCanonicalMap maps the ID of a duplicate counterparty to the ID of its canonical counterparty.
+type CounterpartyCanonicalMap map[string]string
+
+// NewCounterpartyCanonicalMap builds the mapping suggested by the given duplicates.
+func NewCounterpartyCanonicalMap(duplicates []*CounterpartyDuplicate) CounterpartyCanonicalMap {
+	m := CounterpartyCanonicalMap{}
+	for _, d := range duplicates {
+		for _, c := range d.Duplicates {
+			if _, ok := m[c.Id]; !ok {
+				m[c.Id] = d.Canonical.Id
+			}
+		}
+	}
+
+	// a canonical counterparty of one group may be a duplicate in another, follow the chain
+	for id := range m {
+		m[id] = m.Canonical(id)
+	}
+
+	return m
+}
+
+// Canonical returns the ID of the canonical counterparty for the given ID.
+func (m CounterpartyCanonicalMap) Canonical(id string) string {
+	seen := map[string]bool{}
+	for {
+		next, ok := m[id]
+		if !ok || seen[next] {
+			return id
+		}
+		seen[id] = true
+		id = next
+	}
+}
+
+// FindByIban returns the canonical counterparty holding an account with the given IBAN, or nil when there is none
+// or the IBAN is empty.
+func (c *CounterpartyService) FindByIban(ctx context.Context, iban string) (*CounterpartyResp, error) {
+	iban = normaliseIban(iban)
+	if iban == "" {
+		return nil, nil
+	}
+	return c.find(ctx, func(a CounterpartyRespAccount) bool {
+		return normaliseIban(a.Iban) == iban
+	})
+}
+
+// FindByEmail returns the canonical counterparty holding an account with the given email, or nil when there is
+// none or the email is empty.
+func (c *CounterpartyService) FindByEmail(ctx context.Context, email string) (*CounterpartyResp, error) {
+	email = normaliseEmail(email)
+	if email == "" {
+		return nil, nil
+	}
+	return c.find(ctx, func(a CounterpartyRespAccount) bool {
+		return normaliseEmail(a.Email) == email
+	})
+}
+
+func (c *CounterpartyService) find(ctx context.Context, match func(a CounterpartyRespAccount) bool) (*CounterpartyResp, error) {
+	counterparties, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return findCanonical(counterparties, match), nil
+}
+
+// findCanonical returns the first counterparty with a matching account, resolved to its canonical counterparty.
+func findCanonical(counterparties []*CounterpartyResp, match func(a CounterpartyRespAccount) bool) *CounterpartyResp {
+	canonical := NewCounterpartyCanonicalMap(FindDuplicateCounterparties(counterparties))
+	byId := make(map[string]*CounterpartyResp, len(counterparties))
+	for _, cp := range counterparties {
+		byId[cp.Id] = cp
+	}
+
+	for _, cp := range counterparties {
+		for _, a := range cp.Accounts {
+			if match(a) {
+				return byId[canonical.Canonical(cp.Id)]
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortCanonicalFirst orders active counterparties before deleted ones and older before newer ones.
+func sortCanonicalFirst(group []*CounterpartyResp) {
+	sort.SliceStable(group, func(i, j int) bool {
+		if group[i].State != group[j].State {
+			return group[i].State == CounterpartyState_ACTIVE
+		}
+		return group[i].CreatedAt.Before(group[j].CreatedAt.Time)
+	})
+}
+
+func normaliseIban(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+func normaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
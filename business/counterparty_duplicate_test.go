@@ -0,0 +1,56 @@
+package business_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func TestCounterpartyFind(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{Counterparties: []*business.CounterpartyResp{
+		// a UK payee, with neither an IBAN nor an email
+		{Id: "uk", Name: "UK payee", State: business.CounterpartyState_ACTIVE, Accounts: []business.CounterpartyRespAccount{{AccountNo: "12345678", SortCode: "223344"}}},
+		{Id: "eu", Name: "EU payee", State: business.CounterpartyState_ACTIVE, Accounts: []business.CounterpartyRespAccount{{Iban: "DE89370400440532013000"}}},
+		{Id: "mail", Name: "Mail payee", State: business.CounterpartyState_ACTIVE, Accounts: []business.CounterpartyRespAccount{{Email: "payee@example.com"}}},
+	}})
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, err := c.Counterparty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		find func() (*business.CounterpartyResp, error)
+		want string
+	}{
+		{"iban", func() (*business.CounterpartyResp, error) { return cp.FindByIban(ctx, "de89 3704 0044 0532 0130 00") }, "eu"},
+		{"unknown iban", func() (*business.CounterpartyResp, error) { return cp.FindByIban(ctx, "GB29NWBK60161331926819") }, ""},
+		{"empty iban", func() (*business.CounterpartyResp, error) { return cp.FindByIban(ctx, "") }, ""},
+		{"blank iban", func() (*business.CounterpartyResp, error) { return cp.FindByIban(ctx, "  ") }, ""},
+		{"email", func() (*business.CounterpartyResp, error) { return cp.FindByEmail(ctx, " Payee@Example.com") }, "mail"},
+		{"empty email", func() (*business.CounterpartyResp, error) { return cp.FindByEmail(ctx, "") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.find()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var id string
+			if got != nil {
+				id = got.Id
+			}
+			if id != tt.want {
+				t.Errorf("got counterparty %q, want %q", id, tt.want)
+			}
+		})
+	}
+}
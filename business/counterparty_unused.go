@@ -0,0 +1,57 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// counterpartyLookback is how far back DeleteCounterpartyIfUnused looks for payments to a counterparty
+const counterpartyLookback = 90 * 24 * time.Hour
+
+// CounterpartyInUseError is returned by DeleteCounterpartyIfUnused when the counterparty is still referenced by
+// transactions; the counterparty is not deleted.
+type CounterpartyInUseError struct {
+	Id string
+	// the pending transactions, scheduled payments included, and the recent ones paying the counterparty
+	Transactions []*TransactionResp
+}
+
+func (e *CounterpartyInUseError) Error() string {
+	return fmt.Sprintf("counterparty: %s is used by %d pending or recent transactions", e.Id, len(e.Transactions))
+}
+
+// DeleteCounterpartyIfUnused deletes a counterparty unless a transaction references it: a pending one, such as a
+// scheduled payment, whatever its age, or any made in the last 90 days. A used counterparty is kept and a
+// *CounterpartyInUseError lists the transactions. With force the check is skipped and the counterparty deleted.
+func (b *Client) DeleteCounterpartyIfUnused(ctx context.Context, id string, force bool) error {
+	counterparty, err := b.Counterparty()
+	if err != nil {
+		return err
+	}
+	if force {
+		return counterparty.Delete(ctx, id)
+	}
+
+	payment, err := b.Payment()
+	if err != nil {
+		return err
+	}
+	since := time.Now().Add(-counterpartyLookback)
+	var used []*TransactionResp
+	pager := payment.ListAll(ctx, &TransactionReq{Counterparty: id})
+	for pager.Next() {
+		tx := pager.Value()
+		if tx.State == PaymentState_PENDING || tx.State == PaymentState_PENDING_APPROVAL || !tx.CreatedAt.Before(since) {
+			used = append(used, tx)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+	if len(used) > 0 {
+		return &CounterpartyInUseError{Id: id, Transactions: used}
+	}
+
+	return counterparty.Delete(ctx, id)
+}
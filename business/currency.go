@@ -0,0 +1,102 @@
+package business
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Currency is an ISO 4217 alphabetic currency code, e.g. GBP.
+type Currency string
+
+// iso4217 maps every active ISO 4217 code to the number of digits of its minor unit.
+var iso4217 = map[Currency]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2, "AZN": 2,
+	"BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0, "BMD": 2, "BND": 2, "BOB": 2, "BRL": 2,
+	"BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHF": 2, "CLP": 0, "CNY": 2,
+	"COP": 2, "CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2, "DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2,
+	"ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2,
+	"GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2,
+	"IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2, "JOD": 3, "JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0,
+	"KPW": 2, "KRW": 0, "KWD": 3, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2,
+	"LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2,
+	"MVR": 2, "MWK": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2,
+	"NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2,
+	"RON": 2, "RSD": 2, "RUB": 2, "RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2,
+	"SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2,
+	"TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0,
+	"USD": 2, "UYU": 2, "UZS": 2, "VES": 2, "VND": 0, "VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XOF": 0,
+	"XPF": 0, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWL": 2,
+}
+
+// SupportedCurrencies are the currencies Revolut Business accounts can hold and exchange between.
+// Revolut adds currencies over time, so requests are not rejected client-side for using another valid code.
+var SupportedCurrencies = []Currency{
+	"AED", "AUD", "BGN", "CAD", "CHF", "CNY", "CZK", "DKK", "EUR", "GBP", "HKD", "HUF", "ILS", "ISK",
+	"JPY", "MXN", "NOK", "NZD", "PLN", "QAR", "RON", "RSD", "SAR", "SEK", "SGD", "THB", "TRY", "USD", "ZAR",
+}
+
+// Validate checks that the currency is an active ISO 4217 code.
+func (c Currency) Validate() error {
+	if _, ok := iso4217[c]; !ok {
+		return fmt.Errorf("currency: %q is not an ISO 4217 currency code", string(c))
+	}
+	return nil
+}
+
+// Supported reports whether the currency is one of SupportedCurrencies.
+func (c Currency) Supported() bool {
+	for _, s := range SupportedCurrencies {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Decimals returns the number of digits of the minor unit of the currency, 2 for unknown codes.
+func (c Currency) Decimals() int {
+	if d, ok := iso4217[c]; ok {
+		return d
+	}
+	return 2
+}
+
+// Round rounds the amount half up to the minor unit of the currency.
+func (c Currency) Round(amount float64) float64 {
+	return RoundingMode_HALF_UP.Round(amount, c.Decimals())
+}
+
+// ToMinor converts an amount in major units to minor units of the currency, rounding half up: 12.345 GBP is
+// 1235 pence, 1234 JPY is 1234 yen and 1.2345 KWD is 1235 fils. It fails for amounts that are not finite or
+// do not fit an int64.
+func (c Currency) ToMinor(amount float64) (int64, error) {
+	units, ok := RoundingMode_HALF_UP.units(amount, c.Decimals())
+	if !ok || !units.IsInt64() {
+		return 0, fmt.Errorf("currency: %v %s cannot be expressed in minor units", amount, string(c))
+	}
+	return units.Int64(), nil
+}
+
+// FromMinor converts minor units of the currency to an amount in major units: 1235 pence is 12.35 GBP and
+// 1235 fils is 1.235 KWD.
+func (c Currency) FromMinor(units int64) float64 {
+	f, _ := new(big.Rat).SetFrac(big.NewInt(units), pow10(c.Decimals())).Float64()
+	return f
+}
+
+// FormatAmount formats the amount with the number of decimals of the currency, rounding half up, e.g. 1234.50
+// for GBP and 1235 for JPY.
+func (c Currency) FormatAmount(amount float64) string {
+	return strconv.FormatFloat(c.Round(amount), 'f', c.Decimals(), 64)
+}
+
+// Minor returns the amount in minor units of its currency, see Currency.ToMinor.
+func (a Amount) Minor() (int64, error) {
+	return Currency(a.Currency).ToMinor(a.Amount)
+}
+
+// AmountFromMinor returns the amount of minor units of the currency, see Currency.FromMinor.
+func AmountFromMinor(units int64, currency Currency) Amount {
+	return Amount{Amount: currency.FromMinor(units), Currency: string(currency)}
+}
@@ -0,0 +1,158 @@
+package business
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+)
+
+// Decimal is the exact decimal text of an amount as Revolut sent it, e.g. "12345678901234.56", which a float64
+// cannot always hold.
+type Decimal string
+
+// Float64 returns the nearest float64 of the decimal, 0 when it is empty or invalid.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+// Rat returns the exact value of the decimal, false when it is empty or invalid.
+func (d Decimal) Rat() (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(d))
+}
+
+// ToMinor returns the decimal in minor units of the currency, rounding half up without going through a float64.
+func (d Decimal) ToMinor(c Currency) (int64, bool) {
+	r, ok := d.Rat()
+	if !ok {
+		return 0, false
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(c.Decimals())))
+
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 && new(big.Int).Lsh(new(big.Int).Abs(rem), 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	return q.Int64(), q.IsInt64()
+}
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// decimalOf returns the decoded text of an amount while it still matches the float64 field, so changing the
+// field in code is not masked by the text decoded before.
+func decimalOf(exact Decimal, f float64) Decimal {
+	if exact != "" && exact.Float64() == f {
+		return exact
+	}
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// optionalDecimalOf is decimalOf for an optional field, empty when the field is nil.
+func optionalDecimalOf(exact Decimal, f *float64) Decimal {
+	if f == nil {
+		return ""
+	}
+	return decimalOf(exact, *f)
+}
+
+// decodeNumber sets the float64 field and its exact text from a decoded number.
+func decodeNumber(n json.Number, f *float64, exact *Decimal) {
+	*exact = Decimal(n)
+	*f = exact.Float64()
+}
+
+// decodeOptionalNumber sets the optional field and its exact text from a decoded number, leaving the field nil
+// when the number was absent or null.
+func decodeOptionalNumber(n json.Number, f **float64, exact *Decimal) {
+	*f = nil
+	if n == "" {
+		return
+	}
+	*f = new(float64)
+	decodeNumber(n, *f, exact)
+}
+
+// Decimal returns the exact amount, see Decimal.
+func (a Amount) Decimal() Decimal {
+	return decimalOf(a.exact, a.Amount)
+}
+
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	type plain Amount
+	raw := struct {
+		*plain
+		Amount json.Number `json:"amount"`
+	}{plain: (*plain)(a)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	decodeNumber(raw.Amount, &a.Amount, &a.exact)
+	return nil
+}
+
+// BalanceDecimal returns the exact balance, see Decimal.
+func (r AccountResp) BalanceDecimal() Decimal {
+	return decimalOf(r.balance, r.Balance)
+}
+
+func (r *AccountResp) UnmarshalJSON(b []byte) error {
+	type plain AccountResp
+	raw := struct {
+		*plain
+		Balance json.Number `json:"balance"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	decodeNumber(raw.Balance, &r.Balance, &r.balance)
+	return nil
+}
+
+// AmountDecimal returns the exact amount of the leg, see Decimal.
+func (l TransactionLeg) AmountDecimal() Decimal {
+	return decimalOf(l.exact.amount, l.Amount)
+}
+
+// FeeDecimal returns the exact fee of the leg, empty without a fee, see Decimal.
+func (l TransactionLeg) FeeDecimal() Decimal {
+	return optionalDecimalOf(l.exact.fee, l.Fee)
+}
+
+// BillAmountDecimal returns the exact billing amount of the leg, empty without one, see Decimal.
+func (l TransactionLeg) BillAmountDecimal() Decimal {
+	return optionalDecimalOf(l.exact.billAmount, l.BillAmount)
+}
+
+// BalanceDecimal returns the exact balance after the leg, empty without one, see Decimal.
+func (l TransactionLeg) BalanceDecimal() Decimal {
+	return optionalDecimalOf(l.exact.balance, l.Balance)
+}
+
+// legDecimals are the exact amounts of a leg
+type legDecimals struct {
+	amount, fee, billAmount, balance Decimal
+}
+
+func (l *TransactionLeg) UnmarshalJSON(b []byte) error {
+	type plain TransactionLeg
+	raw := struct {
+		*plain
+		Amount     json.Number `json:"amount"`
+		Fee        json.Number `json:"fee"`
+		BillAmount json.Number `json:"bill_amount"`
+		Balance    json.Number `json:"balance"`
+	}{plain: (*plain)(l)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	decodeNumber(raw.Amount, &l.Amount, &l.exact.amount)
+	decodeOptionalNumber(raw.Fee, &l.Fee, &l.exact.fee)
+	decodeOptionalNumber(raw.BillAmount, &l.BillAmount, &l.exact.billAmount)
+	decodeOptionalNumber(raw.Balance, &l.Balance, &l.exact.balance)
+	return nil
+}
@@ -0,0 +1,29 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeEach walks a JSON array element by element, calling next with the decoder positioned on each element.
+func decodeEach(r io.Reader, next func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("decode: expected a JSON array, got %v", t)
+	}
+
+	for dec.More() {
+		if err := next(dec); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
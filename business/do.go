@@ -0,0 +1,55 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// Do sends a call to an endpoint the library does not model yet, with the token, rate limiting, retries, logging
+// and tracing of the client's services. The path is relative to the API root and may carry a query, e.g.
+// "accounts/{id}/bank-details" or "transactions?count=10"; escape the IDs it contains with url.PathEscape.
+// A non-nil body is sent as JSON, and a successful response is decoded into out unless out is nil or the
+// response is empty. Like the services, a mutating call is not retried, the library cannot know its request_id.
+// A nil ctx is context.Background.
+func (b *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	if strings.Contains(path, "://") {
+		return errors.New("do: the path must be relative to the API root, not a URL")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	accessToken, err := b.tokens.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	conf := request.Config{
+		Operation:   "do." + strings.ToLower(method),
+		Method:      method,
+		Url:         apiUrl + "/" + strings.TrimPrefix(path, "/"),
+		AccessToken: accessToken,
+		Sandbox:     b.sandbox,
+		Options:     b.options,
+		Context:     ctx,
+	}
+	if body != nil {
+		conf.Body, conf.ContentType = body, request.ContentType_APPLICATION_JSON
+	}
+
+	resp, statusCode, err := request.New(conf)
+	if err != nil {
+		return err
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return errors.New(string(resp))
+	}
+
+	if out == nil || len(resp) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp, out)
+}
@@ -0,0 +1,34 @@
+package business
+
+import (
+	"net/url"
+	"strings"
+)
+
+//go:generate go run ../internal/cmd/coverage -spec=${REVOLUT_OPENAPI_SPEC} -stubs=internal/stubs/stubs.go
+//go:generate go run ../internal/cmd/codegen -spec=${REVOLUT_OPENAPI_SPEC} -out=generated/api.go
+
+const (
+	// apiUrl is the base url of the business API
+	apiUrl = "https://b2b.revolut.com/api/1.0"
+	// consentUrl is the page users grant the application access on
+	consentUrl = "https://business.revolut.com/app-confirm"
+)
+
+// endpoint builds the url of an API resource. Every path segment is escaped on its own,
+// so IDs can never alter the path, and the query is encoded with url.Values.
+func endpoint(query url.Values, segments ...string) string {
+	var sb strings.Builder
+	sb.WriteString(apiUrl)
+	for _, segment := range segments {
+		sb.WriteByte('/')
+		sb.WriteString(url.PathEscape(segment))
+	}
+
+	if len(query) > 0 {
+		sb.WriteByte('?')
+		sb.WriteString(query.Encode())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,321 @@
+package business_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// recorder answers every request with an empty body and records the urls requested, the token ones apart
+type recorder struct {
+	mu    sync.Mutex
+	urls  []string
+	token string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := "{}"
+	if req.URL.Path == "/api/1.0/auth/token" {
+		body = `{"access_token":"token","token_type":"bearer","expires_in":2400}`
+		r.mu.Lock()
+		r.token = req.URL.String()
+		r.mu.Unlock()
+	} else {
+		r.mu.Lock()
+		r.urls = append(r.urls, req.URL.String())
+		r.mu.Unlock()
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	urls := r.urls
+	r.urls = nil
+	return urls
+}
+
+func newRecordingClient(t *testing.T, sandbox bool, opts ...business.Option) (*business.Client, *recorder) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &recorder{}
+	opts = append([]business.Option{business.WithHTTPClient(&http.Client{Transport: rec})}, opts...)
+	c, err := business.NewClient("client", "refresh", key, "example.com", sandbox, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, rec
+}
+
+// id has the characters a path segment must escape
+const id = "a/b c?d#e"
+
+const escaped = "a%2Fb%20c%3Fd%23e"
+
+func TestEndpoints(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	payment := func() *business.PaymentReq {
+		return &business.PaymentReq{RequestId: "r", AccountId: "a", Amount: 1, Currency: "GBP", Receiver: business.PaymentReceiver{CounterpartyId: "c"}}
+	}
+
+	tests := []struct {
+		name string
+		call func(c *business.Client) error
+		want string
+	}{
+		{"account list", func(c *business.Client) error {
+			a, _ := c.Account()
+			_, err := a.List(ctx)
+			return err
+		}, "/accounts"},
+		{"account with id", func(c *business.Client) error {
+			a, _ := c.Account()
+			_, err := a.WithId(ctx, id)
+			return err
+		}, "/accounts/" + escaped},
+		{"account bank details", func(c *business.Client) error {
+			a, _ := c.Account()
+			_, err := a.DetailWithId(ctx, id)
+			return err
+		}, "/accounts/" + escaped + "/bank-details"},
+		{"account statement", func(c *business.Client) error {
+			a, _ := c.Account()
+			_, err := a.Statement(ctx, &business.StatementReq{AccountId: id, From: "2024-01-01", To: "2024-01-31", Format: business.StatementFormat_CSV}, io.Discard)
+			return err
+		}, "/accounts/" + escaped + "/statement?format=csv&from=2024-01-01&to=2024-01-31"},
+		{"counterparty add revolut", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			_, err := cp.AddRevolut(ctx, &business.RevolutCounterpartyReq{ProfileType: business.CounterpartyProfileType_BUSINESS, Email: "a@example.com"})
+			return err
+		}, "/counterparty"},
+		{"counterparty add non revolut", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			_, err := cp.AddNonRevolut(ctx, &business.NonRevolutCounterpartyReq{CompanyName: "Acme", BankCountry: "GB", Currency: "GBP", AccountNo: "12345678", SortCode: "223344"})
+			return err
+		}, "/counterparty"},
+		{"counterparty delete", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			return cp.Delete(ctx, id)
+		}, "/counterparty/" + escaped},
+		{"counterparty with id", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			_, err := cp.WithId(ctx, id)
+			return err
+		}, "/counterparty/" + escaped},
+		{"counterparty list", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			_, err := cp.List(ctx)
+			return err
+		}, "/counterparties"},
+		{"counterparty list page", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			p := cp.ListAll(ctx)
+			p.Next()
+			return p.Err()
+		}, "/counterparties?limit=1000"},
+		{"account name validation", func(c *business.Client) error {
+			cp, _ := c.Counterparty()
+			_, err := cp.ValidateAccountName(ctx, &business.AccountNameReq{AccountNo: "12345678", SortCode: "223344", CompanyName: "Acme"})
+			return err
+		}, "/account-name-validation"},
+		{"card list", func(c *business.Client) error {
+			cards, _ := c.Card()
+			_, err := cards.List(ctx, &business.CardListReq{CreatedBefore: created, Limit: 10})
+			return err
+		}, "/cards?created_before=2024-01-02T03%3A04%3A05Z&limit=10"},
+		{"card with id", func(c *business.Client) error {
+			cards, _ := c.Card()
+			_, err := cards.WithId(ctx, id)
+			return err
+		}, "/cards/" + escaped},
+		{"card sensitive details", func(c *business.Client) error {
+			cards, _ := c.Card()
+			_, err := cards.SensitiveDetails(ctx, id)
+			return err
+		}, "/cards/" + escaped + "/sensitive-details"},
+		{"card freeze", func(c *business.Client) error {
+			cards, _ := c.Card()
+			return cards.Freeze(ctx, id)
+		}, "/cards/" + escaped + "/freeze"},
+		{"card unfreeze", func(c *business.Client) error {
+			cards, _ := c.Card()
+			return cards.Unfreeze(ctx, id)
+		}, "/cards/" + escaped + "/unfreeze"},
+		{"card update", func(c *business.Client) error {
+			cards, _ := c.Card()
+			_, err := cards.Update(ctx, id, &business.CardUpdateReq{Label: "x"})
+			return err
+		}, "/cards/" + escaped},
+		{"card terminate", func(c *business.Client) error {
+			cards, _ := c.Card()
+			return cards.Terminate(ctx, id)
+		}, "/cards/" + escaped},
+		{"payment draft create", func(c *business.Client) error {
+			d, _ := c.PaymentDraft()
+			_, err := d.Create(ctx, &business.PaymentDraftReq{Payments: []business.PaymentDraftPayment{{AccountId: "a", Amount: 1, Currency: "GBP", Reference: "x", Receiver: business.PaymentDraftPaymentReceiver{CounterpartyId: "c"}}}})
+			return err
+		}, "/payment-drafts"},
+		{"payment draft list", func(c *business.Client) error {
+			d, _ := c.PaymentDraft()
+			_, err := d.List(ctx)
+			return err
+		}, "/payment-drafts"},
+		{"payment draft with id", func(c *business.Client) error {
+			d, _ := c.PaymentDraft()
+			_, err := d.WithId(ctx, id)
+			return err
+		}, "/payment-drafts/" + escaped},
+		{"payment draft delete", func(c *business.Client) error {
+			d, _ := c.PaymentDraft()
+			return d.Delete(ctx, id)
+		}, "/payment-drafts/" + escaped},
+		{"webhook set", func(c *business.Client) error {
+			w, _ := c.Webhook()
+			return w.Set(ctx, "https://example.com/hook")
+		}, "/webhook"},
+		{"webhook delete", func(c *business.Client) error {
+			w, _ := c.Webhook()
+			return w.Delete(ctx)
+		}, "/webhook"},
+		{"transfer", func(c *business.Client) error {
+			tr, _ := c.Transfer()
+			_, err := tr.Create(ctx, &business.TransferReq{RequestId: "r", SourceAccountId: "a", TargetAccountId: "b", Amount: 1, Currency: "GBP"})
+			return err
+		}, "/transfer"},
+		{"transfer reasons", func(c *business.Client) error {
+			tr, _ := c.Transfer()
+			_, err := tr.Reasons(ctx)
+			return err
+		}, "/transfer-reasons"},
+		{"pay", func(c *business.Client) error {
+			p, _ := c.Payment()
+			_, err := p.Create(ctx, payment())
+			return err
+		}, "/pay"},
+		{"transaction with id", func(c *business.Client) error {
+			p, _ := c.Payment()
+			_, err := p.WithId(ctx, id)
+			return err
+		}, "/transaction/" + escaped},
+		{"transaction with request id", func(c *business.Client) error {
+			p, _ := c.Payment()
+			_, err := p.WithRequestId(ctx, id)
+			return err
+		}, "/transaction/" + escaped + "?id_type=request_id"},
+		{"transaction cancel", func(c *business.Client) error {
+			p, _ := c.Payment()
+			return p.Cancel(ctx, id)
+		}, "/transaction/" + escaped},
+		{"transactions", func(c *business.Client) error {
+			p, _ := c.Payment()
+			_, err := p.List(ctx, &business.TransactionReq{From: "2024-01-01T00:00:00Z", To: "2024-02-01", Counterparty: "c&d", Count: 5, Type: business.PaymentType_CARD_PAYMENT})
+			return err
+		}, "/transactions?count=5&counterparty=c%26d&from=2024-01-01T00%3A00%3A00Z&to=2024-02-01&type=card_payment"},
+		{"transactions each", func(c *business.Client) error {
+			p, _ := c.Payment()
+			return p.ListEach(ctx, &business.TransactionReq{Count: 5}, func(*business.TransactionResp) error { return nil })
+		}, "/transactions?count=5"},
+		{"rate", func(c *business.Client) error {
+			e, _ := c.Exchange()
+			_, err := e.Rate(ctx, &business.ExchangeRateReq{From: "GBP", To: "JPY", Amount: 1.5})
+			return err
+		}, "/rate?amount=1.50&from=GBP&to=JPY"},
+		{"exchange", func(c *business.Client) error {
+			e, _ := c.Exchange()
+			_, err := e.Exchange(ctx, &business.ExchangeReq{RequestId: "r",
+				From: business.ExchangeAmount{AccountId: "a", Currency: "GBP", Amount: 1},
+				To:   business.ExchangeAmount{AccountId: "b", Currency: "EUR"},
+			})
+			return err
+		}, "/exchange"},
+		{"revoke", func(c *business.Client) error {
+			return c.Revoke(ctx)
+		}, "/auth/revoke"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, false, business.WithSensitiveCardDetails())
+			// the canned empty responses fail to decode or have the wrong status, only the url matters
+			_ = tt.call(c)
+
+			urls := rec.take()
+			if len(urls) == 0 {
+				t.Fatal("no request sent")
+			}
+			if want := "https://b2b.revolut.com/api/1.0" + tt.want; urls[0] != want {
+				t.Errorf("got %s, want %s", urls[0], want)
+			}
+		})
+	}
+}
+
+func TestTokenEndpoint(t *testing.T) {
+	c, rec := newRecordingClient(t, false)
+	a, err := c.Account()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = a.List(context.Background())
+
+	if want := "https://b2b.revolut.com/api/1.0/auth/token"; rec.token != want {
+		t.Errorf("got %s, want %s", rec.token, want)
+	}
+}
+
+func TestSandboxEndpoints(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(s business.Simulator) error
+		want string
+	}{
+		{"top up", func(s business.Simulator) error {
+			_, err := s.TopUp(ctx, &business.TopUpReq{AccountId: "a", Amount: 1, Currency: "GBP"})
+			return err
+		}, "/sandbox/topup"},
+		{"simulate", func(s business.Simulator) error {
+			_, err := s.Simulate(ctx, id, business.SimulationAction_COMPLETE)
+			return err
+		}, "/sandbox/transactions/" + escaped + "/complete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, true)
+			s, err := c.Sandbox()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = tt.call(s)
+
+			urls := rec.take()
+			if len(urls) == 0 {
+				t.Fatal("no request sent")
+			}
+			if want := "https://sandbox-b2b.revolut.com/api/1.0" + tt.want; urls[0] != want {
+				t.Errorf("got %s, want %s", urls[0], want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package enrich
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// Labels holds the locally assigned category and tags of a transaction.
+type Labels struct {
+	// the category of the transaction, set by the first matching rule
+	Category string `json:"category,omitempty"`
+	// the tags of the transaction, collected from every matching rule
+	Tags []string `json:"tags,omitempty"`
+}
+
+func (e *Labels) tag(category string, tags []string) {
+	if e.Category == "" {
+		e.Category = category
+	}
+	for _, t := range tags {
+		if !e.HasTag(t) {
+			e.Tags = append(e.Tags, t)
+		}
+	}
+}
+
+// HasTag reports whether the enrichment carries the given tag.
+func (e *Labels) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule assigns a category and tags to the transactions it matches.
+type Rule interface {
+	Apply(tx *business.TransactionResp, e *Labels)
+}
+
+// CounterpartyRule matches transactions whose leg counterparty ID or merchant name matches Pattern.
+type CounterpartyRule struct {
+	Pattern  *regexp.Regexp
+	Category string
+	Tags     []string
+}
+
+func (r *CounterpartyRule) Apply(tx *business.TransactionResp, e *Labels) {
+	if tx.Merchant != nil && r.Pattern.MatchString(tx.Merchant.Name) {
+		e.tag(r.Category, r.Tags)
+		return
+	}
+	for _, leg := range tx.Legs {
+		if leg.Counterparty.Id != "" && r.Pattern.MatchString(leg.Counterparty.Id) {
+			e.tag(r.Category, r.Tags)
+			return
+		}
+	}
+}
+
+// ReferenceRule matches transactions whose reference or leg description matches Pattern.
+type ReferenceRule struct {
+	Pattern  *regexp.Regexp
+	Category string
+	Tags     []string
+}
+
+func (r *ReferenceRule) Apply(tx *business.TransactionResp, e *Labels) {
+	if r.Pattern.MatchString(tx.Reference) {
+		e.tag(r.Category, r.Tags)
+		return
+	}
+	for _, leg := range tx.Legs {
+		if r.Pattern.MatchString(leg.Description) {
+			e.tag(r.Category, r.Tags)
+			return
+		}
+	}
+}
+
+// MCCRule matches card transactions by merchant category code, transactions without a merchant never match.
+type MCCRule struct {
+	Codes    []string
+	Category string
+	Tags     []string
+}
+
+func (r *MCCRule) Apply(tx *business.TransactionResp, e *Labels) {
+	if tx.Merchant == nil || tx.Merchant.CategoryCode == "" {
+		return
+	}
+	for _, code := range r.Codes {
+		if code == tx.Merchant.CategoryCode {
+			e.tag(r.Category, r.Tags)
+			return
+		}
+	}
+}
+
+// Store persists enrichments by transaction ID.
+type Store interface {
+	Save(transactionId string, e *Labels) error
+	// Load returns nil without error when the transaction has not been enriched
+	Load(transactionId string) (*Labels, error)
+}
+
+// MemoryStore is an in-memory Store safe for concurrent use.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	enrichments map[string]*Labels
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{enrichments: map[string]*Labels{}}
+}
+
+func (s *MemoryStore) Save(transactionId string, e *Labels) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enrichments[transactionId] = e
+	return nil
+}
+
+func (s *MemoryStore) Load(transactionId string) (*Labels, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.enrichments[transactionId], nil
+}
+
+// Pipeline runs its rules in order over transactions and persists the result.
+type Pipeline struct {
+	Rules []Rule
+	// an optional store the enrichments are saved to
+	Store Store
+}
+
+// Enrich applies the rules to every transaction and returns the enrichments keyed by transaction ID.
+func (p *Pipeline) Enrich(txs []*business.TransactionResp) (map[string]*Labels, error) {
+	r := make(map[string]*Labels, len(txs))
+	for _, tx := range txs {
+		e := &Labels{}
+		for _, rule := range p.Rules {
+			rule.Apply(tx, e)
+		}
+
+		if p.Store != nil {
+			if err := p.Store.Save(tx.Id, e); err != nil {
+				return nil, err
+			}
+		}
+		r[tx.Id] = e
+	}
+
+	return r, nil
+}
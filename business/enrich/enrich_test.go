@@ -0,0 +1,53 @@
+package enrich_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/enrich"
+)
+
+func TestPipelineEnrich(t *testing.T) {
+	store := enrich.NewMemoryStore()
+	p := &enrich.Pipeline{
+		Rules: []enrich.Rule{
+			&enrich.MCCRule{Codes: []string{"5812", "5814"}, Category: "meals", Tags: []string{"card"}},
+			&enrich.CounterpartyRule{Pattern: regexp.MustCompile(`(?i)github|aws`), Category: "software", Tags: []string{"subscription"}},
+			&enrich.ReferenceRule{Pattern: regexp.MustCompile(`(?i)invoice`), Category: "suppliers", Tags: []string{"invoice", "card"}},
+		},
+		Store: store,
+	}
+	txs := []*business.TransactionResp{
+		{Id: "lunch", Merchant: &business.TransactionMerchant{Name: "Pret", CategoryCode: "5814"}},
+		// the first rule matching sets the category, every rule matching adds its tags
+		{Id: "github", Reference: "Invoice 42", Merchant: &business.TransactionMerchant{Name: "GitHub", CategoryCode: "5734"}},
+		{Id: "supplier", Legs: []business.TransactionLeg{{Counterparty: business.LegCounterparty{Id: "aws-emea"}, Description: "To AWS"}}},
+		{Id: "invoice", Legs: []business.TransactionLeg{{Description: "INVOICE 7"}}},
+		{Id: "other", Reference: "Rent"},
+	}
+
+	got, err := p.Enrich(txs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*enrich.Labels{
+		"lunch":    {Category: "meals", Tags: []string{"card"}},
+		"github":   {Category: "software", Tags: []string{"subscription", "invoice", "card"}},
+		"supplier": {Category: "software", Tags: []string{"subscription"}},
+		"invoice":  {Category: "suppliers", Tags: []string{"invoice", "card"}},
+		"other":    {},
+	}
+	for id, labels := range want {
+		if !reflect.DeepEqual(got[id], labels) {
+			t.Errorf("%s: got %+v, want %+v", id, got[id], labels)
+		}
+		if saved, err := store.Load(id); err != nil || saved != got[id] {
+			t.Errorf("%s: got %+v, %v saved, want the labels", id, saved, err)
+		}
+	}
+	if saved, err := store.Load("unknown"); saved != nil || err != nil {
+		t.Errorf("got %+v, %v for a transaction never enriched, want nil", saved, err)
+	}
+}
@@ -0,0 +1,67 @@
+package business
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NewClientFromEnv returns a client configured from the environment:
+//
+//	REVOLUT_CLIENT_ID           the client ID of the API certificate
+//	REVOLUT_PRIVATE_KEY         the PEM private key, or
+//	REVOLUT_PRIVATE_KEY_FILE    the path of the PEM private key
+//	REVOLUT_ISSUER              the JWT issuer, the redirect domain of the certificate
+//	REVOLUT_SANDBOX             "true" to use the sandbox
+//	REVOLUT_REFRESH_TOKEN       the refresh token obtained on consent
+//
+// A REVOLUT_PRIVATE_KEY holding no PEM block is read as a path, as the CLI does.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	for _, name := range []string{"REVOLUT_CLIENT_ID", "REVOLUT_ISSUER", "REVOLUT_REFRESH_TOKEN"} {
+		if os.Getenv(name) == "" {
+			return nil, fmt.Errorf("env: %s is not set", name)
+		}
+	}
+
+	key, err := privateKeyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var sandbox bool
+	if s := os.Getenv("REVOLUT_SANDBOX"); s != "" {
+		if sandbox, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("env: REVOLUT_SANDBOX: %w", err)
+		}
+	}
+
+	return NewClient(os.Getenv("REVOLUT_CLIENT_ID"), os.Getenv("REVOLUT_REFRESH_TOKEN"), key,
+		os.Getenv("REVOLUT_ISSUER"), sandbox, opts...)
+}
+
+func privateKeyFromEnv() (crypto.Signer, error) {
+	pem, path := os.Getenv("REVOLUT_PRIVATE_KEY"), os.Getenv("REVOLUT_PRIVATE_KEY_FILE")
+	switch {
+	case pem != "" && path != "":
+		return nil, errors.New("env: REVOLUT_PRIVATE_KEY and REVOLUT_PRIVATE_KEY_FILE are both set")
+	case pem != "" && strings.Contains(pem, "-----BEGIN"):
+		key, err := LoadPrivateKeyFromPEM([]byte(pem), nil)
+		if err != nil {
+			return nil, fmt.Errorf("env: REVOLUT_PRIVATE_KEY: %w", err)
+		}
+		return key, nil
+	case pem != "":
+		path = pem
+	case path == "":
+		return nil, errors.New("env: REVOLUT_PRIVATE_KEY or REVOLUT_PRIVATE_KEY_FILE is not set")
+	}
+
+	key, err := LoadPrivateKeyFromFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("env: %w", err)
+	}
+	return key, nil
+}
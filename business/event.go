@@ -0,0 +1,87 @@
+package business
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is a typed signal published by the library on an EventBus.
+type Event interface {
+	EventName() string
+}
+
+const (
+	EventName_TOKEN_REFRESHED      = "token_refreshed"
+	EventName_TOKEN_REFRESH_FAILED = "token_refresh_failed"
+	EventName_CONSENT_EXPIRING     = "consent_expiring"
+	EventName_CONSENT_REVOKED      = "consent_revoked"
+	EventName_APPROVAL_REQUIRED    = "approval_required"
+	EventName_APPROVAL_DECIDED     = "approval_decided"
+)
+
+// EventBus dispatches events to the handlers subscribed to their name. Handlers run synchronously
+// on the publishing goroutine, so they must not block. It is safe for concurrent use.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(Event)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: map[string][]func(Event){}}
+}
+
+// Subscribe registers a handler for events with the given name, or for all events when the name is empty.
+func (b *EventBus) Subscribe(name string, handler func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish hands the event to every matching handler.
+func (b *EventBus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := append(append([]func(Event){}, b.handlers[e.EventName()]...), b.handlers[""]...)
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
+
+// TokenRefreshed is published after a new access token was obtained.
+type TokenRefreshed struct {
+	// the instant the new access token expires
+	ExpiresAt time.Time
+	// whether Revolut rotated the refresh token
+	RefreshTokenRotated bool
+}
+
+func (TokenRefreshed) EventName() string { return EventName_TOKEN_REFRESHED }
+
+// TokenRefreshFailed is published when an access token could not be refreshed.
+type TokenRefreshFailed struct {
+	Err error
+}
+
+func (TokenRefreshFailed) EventName() string { return EventName_TOKEN_REFRESH_FAILED }
+
+// ConsentExpiring is published on every refresh once the user's consent is about to expire.
+type ConsentExpiring struct {
+	// the instant the consent, and with it the refresh token, expires
+	ExpiresAt time.Time
+}
+
+func (ConsentExpiring) EventName() string { return EventName_CONSENT_EXPIRING }
+
+// ConsentRevoked is published when Revolut rejects the refresh token, the user has to consent again.
+// It is also published, without error, when the application revokes the refresh token itself.
+type ConsentRevoked struct {
+	Err error
+}
+
+func (ConsentRevoked) EventName() string { return EventName_CONSENT_REVOKED }
@@ -0,0 +1,205 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type ExchangeService struct {
+	accessToken string
+	sandbox     bool
+	options     *request.Options
+	rounding    *Rounding
+}
+
+type ExchangeRateReq struct {
+	// the currency you would like to exchange from
+	From Currency
+	// the currency you would like to exchange to
+	To Currency
+	// exchange amount, default is 1.00 when zero, the parameter being omitted. It is sent with the decimals of
+	// From, rounded by the rounding policy; a positive amount rounding to zero is rejected
+	Amount float64
+	// an optional exact amount sent as is instead of Amount, e.g. the decimal a user typed
+	Exact Decimal
+}
+
+type ExchangeRateResp struct {
+	// information about the currency to exchange from
+	From Amount `json:"from"`
+	// information about the currency to exchange to
+	To Amount `json:"to"`
+	// exchange rate
+	Rate float64 `json:"rate"`
+	// fee for the operation
+	Fee Amount `json:"fee"`
+	// date of proposed exchange rate
+	RateDate Time `json:"rate_date"`
+}
+
+type Amount struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	// the amount as decoded, see Decimal
+	exact Decimal
+}
+
+// ExchangeReq is an exchange between two accounts of the business. Exactly one side carries the amount: on From
+// to sell exactly that amount, on To to buy exactly that amount of the target currency, the amount sold then
+// following from the rate. Validate enforces it.
+type ExchangeReq struct {
+	// information about the account you want to exchange from
+	From ExchangeAmount `json:"from"`
+	// information about the account you want to exchange to
+	To ExchangeAmount `json:"to"`
+	// a user-provided exchange reference
+	Reference string `json:"reference"`
+	// a unique value used to handle duplicates submitted as a result of lost connection or another client error (40 characters max)
+	RequestId string `json:"request_id"`
+}
+type ExchangeAmount struct {
+	// the account ID
+	AccountId string `json:"account_id"`
+	// the amount sold on From or bought on To, zero on the other side
+	Amount   float64  `json:"amount,omitempty"`
+	Currency Currency `json:"currency"`
+}
+
+type ExchangeResp struct {
+	// the ID of transaction
+	Id string `json:"id"`
+	// the request ID the exchange was made with, set from the request as the API does not return it
+	RequestId string `json:"request_id,omitempty"`
+	// the transction state: pending, completed, declined, failed or reverted
+	State PaymentState `json:"state"`
+	// reason code for declined or failed transaction state
+	ReasonCode ReasonCode `json:"reason_code"`
+	// the instant when the transaction was created
+	CreatedAt Time `json:"created_at"`
+	// the instant when the transaction was completed, nil unless completed
+	CompletedAt *Time `json:"completed_at,omitempty"`
+}
+
+// Rate:
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#exchanges-get-exchange-rates
+func (e *ExchangeService) Rate(ctx context.Context, exchangeRateReq *ExchangeRateReq) (*ExchangeRateResp, error) {
+	if err := exchangeRateReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("from", string(exchangeRateReq.From))
+	params.Add("to", string(exchangeRateReq.To))
+	amount, err := e.amountParam(ctx, exchangeRateReq)
+	if err != nil {
+		return nil, err
+	}
+	if amount != "" {
+		params.Add("amount", amount)
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "exchange.rate",
+		Method:      http.MethodGet,
+		Url:         endpoint(params, "rate"),
+		AccessToken: e.accessToken,
+		Sandbox:     e.sandbox,
+		Options:     e.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &ExchangeRateResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Exchange: To check the exchange rate and fees for the operation, please use the /rate endpoint. Without a
+// RequestId, one is generated and returned on the response, exchangeReq is not modified: set RequestId before the
+// call to retry it safely after an error.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#exchanges-exchange-currency
+func (e *ExchangeService) Exchange(ctx context.Context, exchangeReq *ExchangeReq) (*ExchangeResp, error) {
+	// a missing request ID is generated on a copy, the request of the caller is left untouched
+	generated := exchangeReq.RequestId == ""
+	if generated {
+		id, err := e.options.NewId()
+		if err != nil {
+			return nil, err
+		}
+		req := *exchangeReq
+		req.RequestId = id
+		exchangeReq = &req
+	}
+	if err := exchangeReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:      "exchange.exchange",
+		Method:         http.MethodPost,
+		Url:            endpoint(nil, "exchange"),
+		AccessToken:    e.accessToken,
+		Sandbox:        e.sandbox,
+		Options:        e.options,
+		Context:        ctx,
+		Body:           exchangeReq,
+		ContentType:    request.ContentType_APPLICATION_JSON,
+		IdempotencyKey: exchangeReq.RequestId,
+		GeneratedKey:   generated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &ExchangeResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+	r.RequestId = exchangeReq.RequestId
+
+	return r, nil
+}
+
+// amountParam formats the amount of a rate request with the decimals of its source currency, 0 decimals for JPY
+// and 3 for KWD, unless it is exact. It is empty for a zero amount, which the API rejects: the parameter is
+// omitted and the API quotes its default amount.
+func (e *ExchangeService) amountParam(ctx context.Context, exchangeRateReq *ExchangeRateReq) (string, error) {
+	if exchangeRateReq.Exact != "" {
+		return exchangeRateReq.Exact.String(), nil
+	}
+	if exchangeRateReq.Amount == 0 {
+		return "", nil
+	}
+	decimals := exchangeRateReq.From.Decimals()
+	// the rounding policy may round down what Validate rounded half up, e.g. 0.5 JPY
+	amount := e.round(ctx, "exchange.rate", exchangeRateReq.Amount, decimals)
+	if amount == 0 {
+		return "", invalid("amount", fmt.Sprintf("rounds to zero for %s", string(exchangeRateReq.From)))
+	}
+	return strconv.FormatFloat(amount, 'f', decimals, 64), nil
+}
+
+func (e *ExchangeService) round(ctx context.Context, operation string, amount float64, decimals int) float64 {
+	if e.rounding == nil {
+		return RoundingMode_HALF_UP.Round(amount, decimals)
+	}
+	return e.rounding.Round(ctx, operation, amount, decimals)
+}
@@ -0,0 +1,109 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func TestRateAmount(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{Rates: map[string]float64{"GBP/EUR": 1.2, "JPY/GBP": 0.005}})
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := c.Exchange()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  business.ExchangeRateReq
+		// the amount quoted by the server
+		want float64
+	}{
+		{"omitted", business.ExchangeRateReq{From: "GBP", To: "EUR"}, 1},
+		{"explicit", business.ExchangeRateReq{From: "GBP", To: "EUR", Amount: 250}, 250},
+		{"explicit rounded", business.ExchangeRateReq{From: "JPY", To: "GBP", Amount: 1234.4}, 1234},
+		{"exact", business.ExchangeRateReq{From: "GBP", To: "EUR", Exact: "0.50"}, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := e.Rate(context.Background(), &tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.From.Amount != tt.want {
+				t.Errorf("got a quote of %v, want %v", r.From.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateAmountParam(t *testing.T) {
+	tests := []struct {
+		name string
+		req  business.ExchangeRateReq
+		want string
+	}{
+		{"omitted", business.ExchangeRateReq{From: "GBP", To: "EUR"}, "/rate?from=GBP&to=EUR"},
+		{"explicit", business.ExchangeRateReq{From: "GBP", To: "EUR", Amount: 1.5}, "/rate?amount=1.50&from=GBP&to=EUR"},
+		{"no decimals", business.ExchangeRateReq{From: "JPY", To: "GBP", Amount: 1234.4}, "/rate?amount=1234&from=JPY&to=GBP"},
+		{"three decimals", business.ExchangeRateReq{From: "KWD", To: "GBP", Amount: 1.2345}, "/rate?amount=1.235&from=KWD&to=GBP"},
+		{"exact", business.ExchangeRateReq{From: "GBP", To: "EUR", Exact: "0.125"}, "/rate?amount=0.125&from=GBP&to=EUR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, false)
+			e, err := c.Exchange()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = e.Rate(context.Background(), &tt.req)
+
+			urls := rec.take()
+			if len(urls) != 1 {
+				t.Fatalf("got %d requests, want 1", len(urls))
+			}
+			if want := "https://b2b.revolut.com/api/1.0" + tt.want; urls[0] != want {
+				t.Errorf("got %s, want %s", urls[0], want)
+			}
+		})
+	}
+}
+
+func TestRateAmountRoundsToZero(t *testing.T) {
+	tests := []struct {
+		name string
+		mode business.RoundingMode
+		req  business.ExchangeRateReq
+	}{
+		{"half up", business.RoundingMode_HALF_UP, business.ExchangeRateReq{From: "JPY", To: "GBP", Amount: 0.3}},
+		{"pence", business.RoundingMode_HALF_UP, business.ExchangeRateReq{From: "GBP", To: "EUR", Amount: 0.004}},
+		// Validate rounds half up, the policy of the client rounds it down
+		{"truncate", business.RoundingMode_TRUNCATE, business.ExchangeRateReq{From: "JPY", To: "GBP", Amount: 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, false, business.WithRoundingMode(tt.mode))
+			e, err := c.Exchange()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = e.Rate(context.Background(), &tt.req)
+
+			var v *business.ValidationError
+			if !errors.As(err, &v) || v.Field != "amount" {
+				t.Fatalf("got %v, want an amount validation error", err)
+			}
+			if urls := rec.take(); len(urls) != 0 {
+				t.Errorf("sent %v", urls)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+const camtNamespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+type camtDoc struct {
+	XMLName xml.Name   `xml:"Document"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	MsgId   string     `xml:"BkToCstmrStmt>GrpHdr>MsgId"`
+	Created string     `xml:"BkToCstmrStmt>GrpHdr>CreDtTm"`
+	Stmts   []camtStmt `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStmt struct {
+	Id      string      `xml:"Id"`
+	Created string      `xml:"CreDtTm"`
+	From    string      `xml:"FrToDt>FrDtTm"`
+	To      string      `xml:"FrToDt>ToDtTm"`
+	Account string      `xml:"Acct>Id>Othr>Id"`
+	Ccy     string      `xml:"Acct>Ccy"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Status    string     `xml:"Sts"`
+	Booked    string     `xml:"BookgDt>Dt"`
+	Value     string     `xml:"ValDt>Dt"`
+	Ref       string     `xml:"AcctSvcrRef"`
+	Code      string     `xml:"BkTxCd>Prtry>Cd"`
+	Details   camtTxDtls `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTxDtls struct {
+	Refs  *camtRefs `xml:"Refs,omitempty"`
+	Remit string    `xml:"RmtInf>Ustrd,omitempty"`
+}
+
+type camtRefs struct {
+	EndToEnd string `xml:"EndToEndId"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+// CAMT053 writes a CAMT.053-like bank to customer statement, one Stmt per account and one Ntry per
+// transaction leg. It covers the entry fields accounting imports read and is not schema complete.
+func (e *Format) CAMT053(w io.Writer, txs []*business.TransactionResp) error {
+	now := e.in(time.Now())
+	doc := camtDoc{
+		Xmlns:   camtNamespace,
+		MsgId:   now.Format("20060102150405"),
+		Created: now.Format(time.RFC3339),
+	}
+
+	accounts, grouped := byAccount(Rows(txs))
+	for _, account := range accounts {
+		rows := grouped[account]
+		stmt := camtStmt{
+			Id:      account + "-" + doc.MsgId,
+			Created: doc.Created,
+			Account: account,
+			Ccy:     rows[0].Leg.Currency,
+		}
+
+		var start, end time.Time
+		for i, r := range rows {
+			if i == 0 || r.Tx.CreatedAt.Before(start) {
+				start = r.Tx.CreatedAt.Time
+			}
+			if i == 0 || r.Tx.CreatedAt.After(end) {
+				end = r.Tx.CreatedAt.Time
+			}
+
+			entry := camtEntry{
+				Amount:    camtAmount{Ccy: r.Leg.Currency, Value: formatAmount(math.Abs(r.Leg.Amount))},
+				Indicator: "CRDT",
+				Status:    camtStatus(r.Tx.State),
+				Booked:    e.in(r.Tx.CreatedAt.Time).Format("2006-01-02"),
+				Value:     e.in(r.Tx.CreatedAt.Time).Format("2006-01-02"),
+				Ref:       r.Tx.Id,
+				Code:      string(r.Tx.Type),
+				Details:   camtTxDtls{Remit: r.Tx.Reference},
+			}
+			if r.Tx.RequestId != "" {
+				entry.Details.Refs = &camtRefs{EndToEnd: r.Tx.RequestId}
+			}
+			if r.Leg.Amount < 0 {
+				entry.Indicator = "DBIT"
+			}
+			if r.Tx.CompletedAt != nil {
+				entry.Value = e.in(r.Tx.CompletedAt.Time).Format("2006-01-02")
+			}
+			stmt.Entries = append(stmt.Entries, entry)
+		}
+		stmt.From, stmt.To = e.in(start).Format(time.RFC3339), e.in(end).Format(time.RFC3339)
+
+		doc.Stmts = append(doc.Stmts, stmt)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func camtStatus(s business.PaymentState) string {
+	if s == business.PaymentState_COMPLETE {
+		return "BOOK"
+	}
+	return "PDNG"
+}
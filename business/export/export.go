@@ -0,0 +1,151 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// Row is a single leg of a transaction, the unit every format exports.
+type Row struct {
+	Tx  *business.TransactionResp
+	Leg *business.TransactionLeg
+}
+
+// Column maps a row to one CSV field.
+type Column struct {
+	Header string
+	Value  func(e *Format, r *Row) string
+}
+
+var (
+	Column_DATE = Column{"date", func(e *Format, r *Row) string {
+		return e.date(r.Tx.CreatedAt.Time)
+	}}
+	Column_ID = Column{"id", func(e *Format, r *Row) string {
+		return r.Tx.Id
+	}}
+	Column_TYPE = Column{"type", func(e *Format, r *Row) string {
+		return string(r.Tx.Type)
+	}}
+	Column_STATE = Column{"state", func(e *Format, r *Row) string {
+		return string(r.Tx.State)
+	}}
+	Column_ACCOUNT = Column{"account_id", func(e *Format, r *Row) string {
+		return r.Leg.AccountId
+	}}
+	Column_AMOUNT = Column{"amount", func(e *Format, r *Row) string {
+		return formatAmount(r.Leg.Amount)
+	}}
+	Column_CURRENCY = Column{"currency", func(e *Format, r *Row) string {
+		return r.Leg.Currency
+	}}
+	Column_COUNTERPARTY = Column{"counterparty", func(e *Format, r *Row) string {
+		if r.Tx.Merchant != nil && r.Tx.Merchant.Name != "" {
+			return r.Tx.Merchant.Name
+		}
+		return r.Leg.Counterparty.Id
+	}}
+	Column_REFERENCE = Column{"reference", func(e *Format, r *Row) string {
+		return r.Tx.Reference
+	}}
+	Column_DESCRIPTION = Column{"description", func(e *Format, r *Row) string {
+		return r.Leg.Description
+	}}
+	Column_BALANCE = Column{"balance", func(e *Format, r *Row) string {
+		if r.Leg.Balance == nil {
+			return ""
+		}
+		return formatAmount(*r.Leg.Balance)
+	}}
+)
+
+// DefaultColumns are the CSV columns used when Format.Columns is empty.
+var DefaultColumns = []Column{
+	Column_DATE, Column_ID, Column_TYPE, Column_STATE, Column_ACCOUNT, Column_AMOUNT,
+	Column_CURRENCY, Column_COUNTERPARTY, Column_REFERENCE, Column_DESCRIPTION,
+}
+
+// Format converts transactions into formats accounting software imports.
+type Format struct {
+	// the CSV columns, DefaultColumns when empty
+	Columns []Column
+	// the timezone dates are written in, UTC when nil
+	Location *time.Location
+	// the layout of CSV dates, 2006-01-02 when empty
+	DateLayout string
+}
+
+// Rows flattens the transactions into one row per leg.
+func Rows(txs []*business.TransactionResp) []Row {
+	var r []Row
+	for _, tx := range txs {
+		for i := range tx.Legs {
+			r = append(r, Row{Tx: tx, Leg: &tx.Legs[i]})
+		}
+	}
+	return r
+}
+
+// CSV writes one line per transaction leg, preceded by a header line.
+func (e *Format) CSV(w io.Writer, txs []*business.TransactionResp) error {
+	columns := e.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.Header
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	for _, r := range Rows(txs) {
+		for i, c := range columns {
+			record[i] = c.Value(e, &r)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (e *Format) in(t time.Time) time.Time {
+	if e.Location == nil {
+		return t.UTC()
+	}
+	return t.In(e.Location)
+}
+
+func (e *Format) date(t time.Time) string {
+	layout := e.DateLayout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	return e.in(t).Format(layout)
+}
+
+// byAccount groups the rows per account, keeping the order in which accounts first appear.
+func byAccount(rows []Row) (accounts []string, grouped map[string][]Row) {
+	grouped = map[string][]Row{}
+	for _, r := range rows {
+		if _, ok := grouped[r.Leg.AccountId]; !ok {
+			accounts = append(accounts, r.Leg.AccountId)
+		}
+		grouped[r.Leg.AccountId] = append(grouped[r.Leg.AccountId], r)
+	}
+	return accounts, grouped
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,150 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// maxFeedText is the longest text written to a bank feed field, in characters
+const maxFeedText = 255
+
+type FeedFormat string
+
+const (
+	// the Xero bank statement template: *Date,*Amount,Payee,Description,Reference,Check Number, dates dd/mm/yyyy
+	FeedFormat_XERO FeedFormat = "xero"
+	// the QuickBooks Online 3-column bank upload: Date,Description,Amount, dates mm/dd/yyyy
+	FeedFormat_QUICKBOOKS FeedFormat = "quickbooks"
+)
+
+// BankFeed writes the statement lines of one account as a CSV file the accounting software imports into a bank
+// account: every completed leg of the account, then its fee as a line of its own. Each line has a feed ID,
+// a hash of the transaction and leg stable across exports: Xero gets it as the check number, and Seen skips the
+// lines fed before whatever the format, so overlapping periods can be exported without duplicates.
+type BankFeed struct {
+	Format FeedFormat
+	// the ID of the Revolut account of the feed
+	AccountId string
+	// the timezone dates are written in, UTC when nil
+	Location *time.Location
+	// an optional guard reporting whether a feed ID was exported before, the line is skipped then; it is called
+	// once per line, and may record the IDs it is given
+	Seen func(id string) bool
+}
+
+// FeedId returns the feed ID of the leg of a row, or of its fee.
+func FeedId(r *Row, fee bool) string {
+	leg := r.Leg.LegId
+	if leg == "" {
+		for i := range r.Tx.Legs {
+			if &r.Tx.Legs[i] == r.Leg {
+				leg = strconv.Itoa(i)
+			}
+		}
+	}
+	key := r.Tx.Id + ":" + leg
+	if fee {
+		key += ":fee"
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// CSV writes the feed lines of the transactions, preceded by the header line of the format.
+func (f *BankFeed) CSV(w io.Writer, txs []*business.TransactionResp) error {
+	var header []string
+	switch f.Format {
+	case FeedFormat_XERO:
+		header = []string{"*Date", "*Amount", "Payee", "Description", "Reference", "Check Number"}
+	case FeedFormat_QUICKBOOKS:
+		header = []string{"Date", "Description", "Amount"}
+	default:
+		return errors.New("export: unknown bank feed format " + strconv.Quote(string(f.Format)))
+	}
+	if f.AccountId == "" {
+		return errors.New("export: the bank feed needs an account ID")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	e := &Format{Location: f.Location}
+	for _, r := range Rows(txs) {
+		if r.Leg.AccountId != f.AccountId || r.Tx.State != business.PaymentState_COMPLETE {
+			continue
+		}
+		date := r.Tx.CreatedAt.Time
+		if r.Tx.CompletedAt != nil {
+			date = r.Tx.CompletedAt.Time
+		}
+		currency := business.Currency(r.Leg.Currency)
+		payee := NormalizeReference(Column_COUNTERPARTY.Value(e, &r))
+		description := NormalizeReference(r.Leg.Description)
+		reference := NormalizeReference(r.Tx.Reference)
+
+		lines := []feedLine{{FeedId(&r, false), r.Leg.Amount, description}}
+		if r.Leg.Fee != nil && *r.Leg.Fee != 0 {
+			lines = append(lines, feedLine{FeedId(&r, true), -*r.Leg.Fee, joinText("Fee", description)})
+		}
+
+		for _, l := range lines {
+			if f.Seen != nil && f.Seen(l.id) {
+				continue
+			}
+			amount := currency.FormatAmount(l.amount)
+			var record []string
+			if f.Format == FeedFormat_XERO {
+				record = []string{e.in(date).Format("02/01/2006"), amount, payee, l.description, reference, l.id}
+			} else {
+				record = []string{e.in(date).Format("01/02/2006"), joinText(l.description, reference), amount}
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type feedLine struct {
+	id          string
+	amount      float64
+	description string
+}
+
+// NormalizeReference cleans a text for a bank feed: control characters and runs of spaces become one space, the
+// characters spreadsheets read as the start of a formula are dropped from the front, and the text is cut to 255
+// characters.
+func NormalizeReference(s string) string {
+	s = strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}), " ")
+	s = strings.TrimLeft(s, "=+-@ ")
+	if r := []rune(s); len(r) > maxFeedText {
+		s = strings.TrimSpace(string(r[:maxFeedText]))
+	}
+	return s
+}
+
+// joinText joins the texts that are not empty, cut to the length of a feed field
+func joinText(texts ...string) string {
+	var parts []string
+	for _, t := range texts {
+		if t != "" {
+			parts = append(parts, t)
+		}
+	}
+	return NormalizeReference(strings.Join(parts, " - "))
+}
@@ -0,0 +1,274 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// Chart maps the transactions onto the ledger accounts of the books, its chart of accounts.
+type Chart interface {
+	// Bank returns the ledger account of a Revolut account of the business
+	Bank(accountId, currency string) string
+	// Counterpart returns the ledger account the other side of a single leg transaction is booked to, e.g. an
+	// expense account for card payments
+	Counterpart(r *Row) string
+	// Fees returns the ledger account of the fees charged by Revolut
+	Fees(currency string) string
+	// FXGainLoss returns the ledger account of the gains and losses made on exchanges
+	FXGainLoss() string
+}
+
+// MapChart is a Chart of fixed ledger accounts, looked up by Revolut account and transaction type.
+type MapChart struct {
+	// the ledger accounts by Revolut account ID
+	Banks map[string]string
+	// the ledger account of the Revolut accounts missing from Banks
+	DefaultBank string
+	// the ledger account of the other side by transaction type, e.g. card_payment to an expense account
+	Types map[business.PaymentType]string
+	// the ledger account of the other side of the types missing from Types, a suspense account to review
+	Suspense string
+	// the ledger account of the fees
+	FeeAccount string
+	// the ledger account of the exchange gains and losses
+	FXAccount string
+}
+
+func (c *MapChart) Bank(accountId, currency string) string {
+	if a, ok := c.Banks[accountId]; ok {
+		return a
+	}
+	return c.DefaultBank
+}
+
+func (c *MapChart) Counterpart(r *Row) string {
+	if a, ok := c.Types[r.Tx.Type]; ok {
+		return a
+	}
+	return c.Suspense
+}
+
+func (c *MapChart) Fees(currency string) string {
+	return c.FeeAccount
+}
+
+func (c *MapChart) FXGainLoss() string {
+	return c.FXAccount
+}
+
+// JournalLine is a debit or a credit of a ledger account. The lines of a transaction balance in the base
+// currency of the journal.
+type JournalLine struct {
+	// the instant the transaction completed
+	Date time.Time
+	// the ID of the transaction, shared by the lines of one journal entry
+	TransactionId string
+	// the ledger account, as the chart maps it
+	Account string
+	// the amount debited in the base currency, zero for a credit
+	Debit float64
+	// the amount credited in the base currency, zero for a debit
+	Credit float64
+	// the amount and currency as Revolut moved them, in the base currency for the gains and losses
+	Amount   float64
+	Currency string
+	// the description of the leg, or what the line is for
+	Description string
+}
+
+// Journal converts transactions into double-entry journal lines for import into an ERP. A transaction with a
+// single leg is booked between the Revolut account and its counterpart; one with several legs, an exchange or a
+// transfer between accounts of the business, between the Revolut accounts, the difference of their values being
+// an exchange gain or loss. Fees are booked apart.
+type Journal struct {
+	// the ledger accounts of the lines
+	Chart Chart
+	// the currency the books are kept in, every line is valued in it
+	BaseCurrency string
+	// returns the value of one unit of a currency in the base currency at an instant, false when unknown. Without
+	// it, or without a rate, the leg of an exchange in a foreign currency takes the value of the other legs and no
+	// gain or loss is made; other legs in a foreign currency cannot be valued and fail the conversion
+	Rate func(currency string, at time.Time) (float64, bool)
+	// the timezone dates are written in, UTC when nil
+	Location *time.Location
+	// the layout of CSV dates, 2006-01-02 when empty
+	DateLayout string
+	// the rounding policy of the values in the base currency, e.g. Client.Rounding() to apply and audit the
+	// rounding mode of the client; half up and unaudited when nil
+	Rounding *business.Rounding
+}
+
+// Lines converts the completed transactions into journal lines, in the order of the transactions. Pending
+// transactions and those declined, failed or reverted move no funds and are left out. The acting user of the
+// context, if any, is recorded with the audited roundings.
+func (j *Journal) Lines(ctx context.Context, txs []*business.TransactionResp) ([]JournalLine, error) {
+	if j.Chart == nil {
+		return nil, errors.New("export: the journal needs a chart of accounts")
+	}
+	if j.BaseCurrency == "" {
+		return nil, errors.New("export: the journal needs a base currency")
+	}
+
+	var lines []JournalLine
+	for _, tx := range txs {
+		if tx.State != business.PaymentState_COMPLETE || len(tx.Legs) == 0 {
+			continue
+		}
+		entry, err := j.entry(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, entry...)
+	}
+	return lines, nil
+}
+
+// entry returns the balanced lines of a transaction
+func (j *Journal) entry(ctx context.Context, tx *business.TransactionResp) ([]JournalLine, error) {
+	at := tx.CreatedAt.Time
+	if tx.CompletedAt != nil {
+		at = tx.CompletedAt.Time
+	}
+	base := business.Currency(j.BaseCurrency)
+
+	values := make([]float64, len(tx.Legs))
+	unknown := -1
+	for i, leg := range tx.Legs {
+		v, ok := j.value(ctx, leg.Amount, leg.Currency, at)
+		if ok {
+			values[i] = v
+			continue
+		}
+		if len(tx.Legs) == 1 || unknown >= 0 {
+			return nil, fmt.Errorf("export: no %s rate in %s for transaction %s", leg.Currency, base, tx.Id)
+		}
+		unknown = i
+	}
+	// the leg that cannot be valued balances the others
+	if unknown >= 0 {
+		for i, v := range values {
+			if i != unknown {
+				values[unknown] -= v
+			}
+		}
+		values[unknown] = j.round(ctx, values[unknown])
+	}
+
+	line := func(account string, value float64, leg *business.TransactionLeg) JournalLine {
+		l := JournalLine{Date: at, TransactionId: tx.Id, Account: account, Amount: leg.Amount, Currency: leg.Currency, Description: leg.Description}
+		if value >= 0 {
+			l.Debit = value
+		} else {
+			l.Credit = -value
+		}
+		return l
+	}
+
+	var lines []JournalLine
+	var imbalance float64
+	for i := range tx.Legs {
+		leg := &tx.Legs[i]
+		lines = append(lines, line(j.Chart.Bank(leg.AccountId, leg.Currency), values[i], leg))
+		if len(tx.Legs) == 1 {
+			l := line(j.Chart.Counterpart(&Row{Tx: tx, Leg: leg}), -values[i], leg)
+			l.Amount = -l.Amount
+			lines = append(lines, l)
+		} else {
+			imbalance += values[i]
+		}
+
+		if leg.Fee == nil || *leg.Fee == 0 {
+			continue
+		}
+		// the fee is valued at the rate of its leg
+		fee := *leg.Fee
+		var fv float64
+		if leg.Amount != 0 {
+			fv = j.round(ctx, fee*values[i]/leg.Amount)
+		} else if v, ok := j.value(ctx, fee, leg.Currency, at); ok {
+			fv = v
+		} else {
+			return nil, fmt.Errorf("export: no %s rate in %s for the fee of transaction %s", leg.Currency, base, tx.Id)
+		}
+		if fv < 0 {
+			fv = -fv
+		}
+		lines = append(lines,
+			JournalLine{Date: at, TransactionId: tx.Id, Account: j.Chart.Fees(leg.Currency), Debit: fv, Amount: fee, Currency: leg.Currency, Description: "Fee"},
+			JournalLine{Date: at, TransactionId: tx.Id, Account: j.Chart.Bank(leg.AccountId, leg.Currency), Credit: fv, Amount: -fee, Currency: leg.Currency, Description: "Fee"},
+		)
+	}
+
+	// the legs of an exchange worth more than they cost are a gain
+	if imbalance = j.round(ctx, imbalance); imbalance != 0 {
+		l := JournalLine{Date: at, TransactionId: tx.Id, Account: j.Chart.FXGainLoss(), Amount: -imbalance, Currency: string(base), Description: "Exchange gain"}
+		if imbalance > 0 {
+			l.Credit = imbalance
+		} else {
+			l.Debit, l.Description = -imbalance, "Exchange loss"
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// value returns the amount in the base currency, false when its rate is unknown
+func (j *Journal) value(ctx context.Context, amount float64, currency string, at time.Time) (float64, bool) {
+	if currency == j.BaseCurrency {
+		return j.round(ctx, amount), true
+	}
+	if j.Rate == nil {
+		return 0, false
+	}
+	rate, ok := j.Rate(currency, at)
+	if !ok {
+		return 0, false
+	}
+	return j.round(ctx, amount*rate), true
+}
+
+// round rounds a value to the minor unit of the base currency with the rounding policy of the journal
+func (j *Journal) round(ctx context.Context, amount float64) float64 {
+	decimals := business.Currency(j.BaseCurrency).Decimals()
+	if j.Rounding == nil {
+		return business.RoundingMode_HALF_UP.Round(amount, decimals)
+	}
+	return j.Rounding.Round(ctx, "export.journal", amount, decimals)
+}
+
+// CSV writes the journal lines of the transactions, one per line, preceded by a header line.
+func (j *Journal) CSV(ctx context.Context, w io.Writer, txs []*business.TransactionResp) error {
+	lines, err := j.Lines(ctx, txs)
+	if err != nil {
+		return err
+	}
+
+	f := &Format{Location: j.Location, DateLayout: j.DateLayout}
+	base := business.Currency(j.BaseCurrency)
+	amount := func(v float64) string {
+		if v == 0 {
+			return ""
+		}
+		return base.FormatAmount(v)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "transaction_id", "account", "debit", "credit", "amount", "currency", "description"}); err != nil {
+		return err
+	}
+	for _, l := range lines {
+		record := []string{f.date(l.Date), l.TransactionId, l.Account, amount(l.Debit), amount(l.Credit), formatAmount(l.Amount), l.Currency, l.Description}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
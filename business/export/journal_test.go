@@ -0,0 +1,97 @@
+package export_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/export"
+)
+
+func TestJournalLines(t *testing.T) {
+	at := business.Time{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	chart := &export.MapChart{
+		Banks:      map[string]string{"gbp": "1200", "eur": "1210"},
+		Types:      map[business.PaymentType]string{business.PaymentType_CARD_PAYMENT: "6000"},
+		Suspense:   "9999",
+		FeeAccount: "6900",
+		FXAccount:  "7900",
+	}
+	card := &business.TransactionResp{Id: "card", Type: business.PaymentType_CARD_PAYMENT, State: business.PaymentState_COMPLETE, CreatedAt: at,
+		Legs: []business.TransactionLeg{{AccountId: "gbp", Amount: -10.009, Currency: "GBP", Description: "Coffee"}}}
+	exchange := &business.TransactionResp{Id: "fx", Type: business.PaymentType_EXCHANGE, State: business.PaymentState_COMPLETE, CreatedAt: at,
+		Legs: []business.TransactionLeg{
+			{AccountId: "gbp", Amount: -100, Currency: "GBP", Description: "Exchange"},
+			{AccountId: "eur", Amount: 120, Currency: "EUR", Description: "Exchange"},
+		}}
+	pending := &business.TransactionResp{Id: "pending", Type: business.PaymentType_CARD_PAYMENT, State: business.PaymentState_PENDING, CreatedAt: at,
+		Legs: []business.TransactionLeg{{AccountId: "gbp", Amount: -5, Currency: "GBP"}}}
+
+	type line struct {
+		account       string
+		debit, credit float64
+	}
+	tests := []struct {
+		name     string
+		rounding *business.Rounding
+		rate     float64
+		want     []line
+	}{
+		{"half up by default", nil, 0.85, []line{
+			{"1200", 0, 10.01}, {"6000", 10.01, 0},
+			{"1200", 0, 100}, {"1210", 102, 0}, {"7900", 0, 2},
+		}},
+		{"rounding policy", &business.Rounding{Mode: business.RoundingMode_TRUNCATE}, 0.85, []line{
+			{"1200", 0, 10}, {"6000", 10, 0},
+			{"1200", 0, 100}, {"1210", 102, 0}, {"7900", 0, 2},
+		}},
+		{"exchange loss", nil, 0.8, []line{
+			{"1200", 0, 10.01}, {"6000", 10.01, 0},
+			{"1200", 0, 100}, {"1210", 96, 0}, {"7900", 4, 0},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &export.Journal{
+				Chart:        chart,
+				BaseCurrency: "GBP",
+				Rate: func(currency string, at time.Time) (float64, bool) {
+					return tt.rate, currency == "EUR"
+				},
+				Rounding: tt.rounding,
+			}
+			lines, err := j.Lines(context.Background(), []*business.TransactionResp{card, pending, exchange})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d: %+v", len(lines), len(tt.want), lines)
+			}
+			for i, l := range lines {
+				if got := (line{l.Account, l.Debit, l.Credit}); got != tt.want[i] {
+					t.Errorf("line %d: got %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJournalRoundingAudit(t *testing.T) {
+	var entries []*business.AuditEntry
+	j := &export.Journal{
+		Chart:        &export.MapChart{DefaultBank: "1200", Suspense: "9999"},
+		BaseCurrency: "GBP",
+		Rounding: &business.Rounding{Mode: business.RoundingMode_HALF_EVEN, Audit: business.AuditLoggerFunc(func(entry *business.AuditEntry) {
+			entries = append(entries, entry)
+		})},
+	}
+	tx := &business.TransactionResp{Id: "card", State: business.PaymentState_COMPLETE,
+		Legs: []business.TransactionLeg{{AccountId: "gbp", Amount: -10.009, Currency: "GBP"}}}
+	if _, err := j.Lines(context.Background(), []*business.TransactionResp{tx}); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != "export.journal" {
+		t.Fatalf("got audit entries %+v, want one export.journal rounding", entries)
+	}
+}
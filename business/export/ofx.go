@@ -0,0 +1,141 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+type ofxDoc struct {
+	XMLName xml.Name  `xml:"OFX"`
+	Stmts   []ofxStmt `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStmt struct {
+	TrnUid  string      `xml:"TRNUID"`
+	Code    int         `xml:"STATUS>CODE"`
+	Sev     string      `xml:"STATUS>SEVERITY"`
+	CurDef  string      `xml:"STMTRS>CURDEF"`
+	AcctId  string      `xml:"STMTRS>BANKACCTFROM>ACCTID"`
+	AcctTy  string      `xml:"STMTRS>BANKACCTFROM>ACCTTYPE"`
+	Start   string      `xml:"STMTRS>BANKTRANLIST>DTSTART"`
+	End     string      `xml:"STMTRS>BANKTRANLIST>DTEND"`
+	Entries []ofxEntry  `xml:"STMTRS>BANKTRANLIST>STMTTRN"`
+	Balance *ofxBalance `xml:"STMTRS>LEDGERBAL,omitempty"`
+}
+
+type ofxEntry struct {
+	TrnType string `xml:"TRNTYPE"`
+	Posted  string `xml:"DTPOSTED"`
+	Amount  string `xml:"TRNAMT"`
+	FitId   string `xml:"FITID"`
+	Name    string `xml:"NAME,omitempty"`
+	Memo    string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// OFX writes an OFX 2.2 bank statement per account, one STMTTRN per transaction leg.
+func (e *Format) OFX(w io.Writer, txs []*business.TransactionResp) error {
+	doc := ofxDoc{}
+	accounts, grouped := byAccount(Rows(txs))
+	for _, account := range accounts {
+		rows := grouped[account]
+		stmt := ofxStmt{
+			TrnUid: account,
+			Sev:    "INFO",
+			CurDef: rows[0].Leg.Currency,
+			AcctId: account,
+			AcctTy: "CHECKING",
+		}
+
+		var start, end time.Time
+		for i, r := range rows {
+			if i == 0 || r.Tx.CreatedAt.Before(start) {
+				start = r.Tx.CreatedAt.Time
+			}
+			if i == 0 || !r.Tx.CreatedAt.Before(end) {
+				end = r.Tx.CreatedAt.Time
+				if r.Leg.Balance != nil {
+					stmt.Balance = &ofxBalance{Amount: formatAmount(*r.Leg.Balance), AsOf: e.ofxTime(end)}
+				}
+			}
+
+			entry := ofxEntry{
+				TrnType: ofxTrnType(r.Tx.Type, r.Leg.Amount),
+				Posted:  e.ofxTime(r.Tx.CreatedAt.Time),
+				Amount:  formatAmount(r.Leg.Amount),
+				FitId:   r.Tx.Id + ":" + r.Leg.LegId,
+				Memo:    r.Tx.Reference,
+			}
+			if r.Tx.Merchant != nil {
+				entry.Name = r.Tx.Merchant.Name
+			}
+			if entry.Memo == "" {
+				entry.Memo = r.Leg.Description
+			}
+			stmt.Entries = append(stmt.Entries, entry)
+		}
+		stmt.Start, stmt.End = e.ofxTime(start), e.ofxTime(end)
+
+		doc.Stmts = append(doc.Stmts, stmt)
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ofxTime formats t as an OFX datetime with its UTC offset, e.g. 20200101120000.000[+1:CET].
+func (e *Format) ofxTime(t time.Time) string {
+	t = e.in(t)
+	name, offset := t.Zone()
+	return t.Format("20060102150405.000") + "[" + formatOffset(offset) + ":" + name + "]"
+}
+
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	s := sign + strconv.Itoa(hours)
+	if minutes != 0 {
+		s += "." + strconv.Itoa(minutes)
+	}
+	return s
+}
+
+func ofxTrnType(t business.PaymentType, amount float64) string {
+	switch t {
+	case business.PaymentType_ATM:
+		return "ATM"
+	case business.PaymentType_CARD_PAYMENT:
+		return "POS"
+	case business.PaymentType_FEE:
+		return "FEE"
+	case business.PaymentType_TRANSFER, business.PaymentType_EXCHANGE:
+		return "XFER"
+	}
+	if amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
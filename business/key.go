@@ -0,0 +1,78 @@
+package business
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// LoadPrivateKeyFromPEM parses the private key used to sign client assertions.
+// It accepts PKCS#1 RSA, SEC 1 EC and PKCS#8 keys. Keys encrypted with the legacy PEM encryption
+// are decrypted with the passphrase, pass nil for unencrypted keys. That encryption is insecure: it derives the key
+// from the passphrase with a single MD5 round and does not authenticate the ciphertext, so a wrong passphrase may
+// go unnoticed and a tampered key may be accepted. Prefer an unencrypted key kept in a secret store.
+func LoadPrivateKeyFromPEM(pemBytes []byte, passphrase []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("oauth: no PEM block found")
+	}
+
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("oauth: encrypted PKCS#8 keys are not supported, decrypt the key first")
+	}
+
+	der := block.Bytes
+	// legacy PEM encryption is deprecated and insecure, see above, but still what most tools produce for PKCS#1 keys
+	if x509.IsEncryptedPEMBlock(block) {
+		if passphrase == nil {
+			return nil, errors.New("oauth: private key is encrypted, a passphrase is required")
+		}
+
+		var err error
+		der, err = x509.DecryptPEMBlock(block, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the parsers return typed nil pointers on error, which would make a non-nil crypto.Signer
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("oauth: unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("oauth: unsupported PEM block type %q", block.Type)
+	}
+}
+
+// LoadPrivateKeyFromFile reads and parses a PEM encoded private key, see LoadPrivateKeyFromPEM.
+func LoadPrivateKeyFromFile(path string, passphrase []byte) (crypto.Signer, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadPrivateKeyFromPEM(b, passphrase)
+}
@@ -0,0 +1,253 @@
+package business
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type OAuthService struct {
+	clientId   string
+	privateKey crypto.Signer
+	issuer     string
+	sandbox    bool
+	options    *request.Options
+
+	// the signed client assertion, reused until shortly before it expires
+	assertionMu     sync.Mutex
+	assertion       string
+	assertionExpiry time.Time
+}
+
+// NewOAuth returns the OAuth service signing its client assertions with the private key,
+// which may be an *rsa.PrivateKey, an *ecdsa.PrivateKey or any crypto.Signer backed by a KMS or HSM.
+func NewOAuth(clientId string, privateKey crypto.Signer, issuer string, sandbox bool) *OAuthService {
+	return &OAuthService{
+		clientId:   clientId,
+		privateKey: privateKey,
+		issuer:     issuer,
+		sandbox:    sandbox,
+		options:    &request.Options{},
+	}
+}
+
+const (
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	aud                 = "https://revolut.com"
+
+	// how long a signed client assertion is valid
+	assertionLifetime = time.Hour
+	// how long before its expiry a cached client assertion is replaced
+	assertionLeeway = 5 * time.Minute
+
+	grant_type_authorization_code = "authorization_code"
+	grant_type_refresh_token      = "refresh_token"
+)
+
+type OAuthResp struct {
+	// the access token
+	AccessToken string `json:"access_token"`
+	// "bearer" means that this token is valid to access the API
+	TokenType string `json:"token_type"`
+	// token expiration time in seconds
+	ExpiresIn int32 `json:"expires_in"`
+	// A token to be used to request a new access token
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ExchangeAuthorisationCode: This endpoint is used to exchange an authorisation code with an access token.
+// doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-oauth-get-authorisation-code
+func (oa *OAuthService) ExchangeAuthorisationCode(ctx context.Context, code string) (*OAuthResp, error) {
+	clientAssertion, err := oa.generateClientAssertion()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation: "oauth.exchange_authorisation_code",
+		Method:    http.MethodPost,
+		Url:       endpoint(nil, "auth", "token"),
+		Sandbox:   oa.sandbox,
+		Options:   oa.options,
+		Context:   ctx,
+		Body: url.Values{
+			// "authorization_code"
+			"grant_type": []string{grant_type_authorization_code},
+			// an authorisation code
+			"code": []string{code},
+			// your app ID
+			"client_id": []string{oa.clientId},
+			// "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+			"client_assertion_type": []string{clientAssertionType},
+			// Your generated JWT token
+			"client_assertion": []string{clientAssertion},
+		},
+		ContentType: request.ContentType_APPLICATION_FORM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &OAuthResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RefreshAccessToken: This endpoint is used to request a new user access token after the expiration date.
+// doc: https://revolut-engineering.github.io/api-docs/#business-api-business-api-oauth-refresh-access-token
+func (oa *OAuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (*OAuthResp, error) {
+	clientAssertion, err := oa.generateClientAssertion()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation: "oauth.refresh_access_token",
+		Method:    http.MethodPost,
+		Url:       endpoint(nil, "auth", "token"),
+		Sandbox:   oa.sandbox,
+		Options:   oa.options,
+		Context:   ctx,
+		Body: url.Values{
+			"grant_type":            []string{grant_type_refresh_token},
+			"refresh_token":         []string{refreshToken},
+			"client_id":             []string{oa.clientId},
+			"client_assertion_type": []string{clientAssertionType},
+			"client_assertion":      []string{clientAssertion},
+		},
+		ContentType: request.ContentType_APPLICATION_FORM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &OAuthResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+type TokenTypeHint string
+
+const (
+	TokenTypeHint_ACCESS_TOKEN  TokenTypeHint = "access_token"
+	TokenTypeHint_REFRESH_TOKEN TokenTypeHint = "refresh_token"
+)
+
+// RevokeToken: Invalidate an access or refresh token, e.g. when off-boarding a customer. Revoking the refresh token
+// ends the consent: the user has to authorise the application again. The call follows RFC 7009.
+// doc: https://datatracker.ietf.org/doc/html/rfc7009#section-2.1
+func (oa *OAuthService) RevokeToken(ctx context.Context, token string, hint TokenTypeHint) error {
+	clientAssertion, err := oa.generateClientAssertion()
+	if err != nil {
+		return err
+	}
+
+	body := url.Values{
+		"token":                 []string{token},
+		"client_id":             []string{oa.clientId},
+		"client_assertion_type": []string{clientAssertionType},
+		"client_assertion":      []string{clientAssertion},
+	}
+	if hint != "" {
+		body.Set("token_type_hint", string(hint))
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "oauth.revoke_token",
+		Method:      http.MethodPost,
+		Url:         endpoint(nil, "auth", "revoke"),
+		Sandbox:     oa.sandbox,
+		Options:     oa.options,
+		Context:     ctx,
+		Body:        body,
+		ContentType: request.ContentType_APPLICATION_FORM,
+	})
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		return errors.New(string(resp))
+	}
+
+	return nil
+}
+
+// AuthorizationURL: Navigate the user to the returned address to request an authorisation code.
+// After consenting the user is redirected to redirectURI with the code and the given state
+// in the query, see ListenForAuthorisationCode for a helper capturing them.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#oauth-get-authorisation-code
+func (oa *OAuthService) AuthorizationURL(redirectURI, state string) (string, error) {
+	params := url.Values{}
+	params.Set("client_id", oa.clientId)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("response_type", "code")
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	u := consentUrl + "?" + params.Encode()
+	if !oa.sandbox {
+		return u, nil
+	}
+
+	return request.SandboxUrl(u)
+}
+
+func (oa *OAuthService) generateClientAssertion() (string, error) {
+	oa.assertionMu.Lock()
+	defer oa.assertionMu.Unlock()
+
+	now := time.Now()
+	if oa.assertion != "" && now.Add(assertionLeeway).Before(oa.assertionExpiry) {
+		return oa.assertion, nil
+	}
+
+	method, err := signingMethodFor(oa.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	expiry := now.Add(assertionLifetime)
+	token := jwt.NewWithClaims(method,
+		jwt.MapClaims{
+			"iss": oa.issuer,
+			"aud": aud,
+			"sub": oa.clientId,
+			"iat": now.Unix(),
+			"nbf": now.Unix(),
+			"exp": expiry.Unix(),
+		})
+
+	signedToken, err := token.SignedString(oa.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	oa.assertion = signedToken
+	oa.assertionExpiry = expiry
+
+	return signedToken, nil
+}
@@ -0,0 +1,71 @@
+package business
+
+// Pager iterates over every item of a list endpoint, fetching the next page when the current one is exhausted.
+//
+//	payments, err := bC.Payment()
+//	...
+//	p := payments.ListAll(ctx, &business.TransactionReq{From: "2020-01-01"})
+//	for p.Next() {
+//		fmt.Println(p.Value())
+//	}
+//	if err := p.Err(); err != nil {
+//		panic(err)
+//	}
+//
+// A pager is not safe for concurrent use, give every goroutine a pager of its own.
+type Pager[T any] struct {
+	fetch func(cursor string) (page []T, next string, err error)
+
+	page   []T
+	i      int
+	cursor string
+	done   bool
+	value  T
+	err    error
+}
+
+// newPager returns a pager over fetch. fetch is called with an empty cursor for the first page and
+// returns the cursor of the following page, an empty one once the list is exhausted.
+func newPager[T any](fetch func(cursor string) ([]T, string, error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch}
+}
+
+// Next advances to the next item, fetching a new page when needed. It returns false once
+// every item was visited or a fetch failed, see Err.
+func (p *Pager[T]) Next() bool {
+	for p.i >= len(p.page) {
+		if p.done || p.err != nil {
+			return false
+		}
+
+		p.page, p.cursor, p.err = p.fetch(p.cursor)
+		p.i = 0
+		if p.err != nil {
+			return false
+		}
+		p.done = p.cursor == ""
+	}
+
+	p.value = p.page[p.i]
+	p.i++
+	return true
+}
+
+// Value returns the current item.
+func (p *Pager[T]) Value() T {
+	return p.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All drains the pager into a slice.
+func (p *Pager[T]) All() ([]T, error) {
+	var r []T
+	for p.Next() {
+		r = append(r, p.Value())
+	}
+	return r, p.Err()
+}
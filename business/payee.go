@@ -0,0 +1,253 @@
+package business
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ibanCurrencies maps the countries issuing IBANs outside the eurozone to the currency of their accounts
+var ibanCurrencies = map[string]Currency{
+	"AE": "AED", "BG": "BGN", "CH": "CHF", "CZ": "CZK", "DK": "DKK", "GB": "GBP", "GI": "GIP", "HU": "HUF",
+	"IL": "ILS", "IS": "ISK", "LI": "CHF", "NO": "NOK", "PL": "PLN", "QA": "QAR", "RO": "RON", "RS": "RSD",
+	"SA": "SAR", "SE": "SEK", "TR": "TRY",
+}
+
+// eurozone are the countries issuing IBANs whose accounts hold euros
+var eurozone = map[string]bool{
+	"AD": true, "AT": true, "BE": true, "CY": true, "DE": true, "EE": true, "ES": true, "FI": true, "FR": true,
+	"GR": true, "HR": true, "IE": true, "IT": true, "LT": true, "LU": true, "LV": true, "MC": true, "ME": true,
+	"MT": true, "NL": true, "PT": true, "SI": true, "SK": true, "SM": true, "VA": true, "XK": true,
+}
+
+// sepa are the countries of the SEPA area
+var sepa = map[string]bool{
+	"BG": true, "CH": true, "CZ": true, "DK": true, "GB": true, "GI": true, "HU": true, "IS": true, "LI": true,
+	"NO": true, "PL": true, "RO": true, "SE": true,
+}
+
+func init() {
+	for country := range eurozone {
+		sepa[country] = true
+	}
+}
+
+// companySuffixes mark the names of companies rather than individuals
+var companySuffixes = map[string]bool{
+	"AB": true, "AG": true, "APS": true, "AS": true, "BV": true, "CO": true, "CORP": true, "CORPORATION": true,
+	"GMBH": true, "INC": true, "KG": true, "LIMITED": true, "LLC": true, "LLP": true, "LTD": true, "NV": true,
+	"OY": true, "PLC": true, "SA": true, "SARL": true, "SAS": true, "SL": true, "SPA": true, "SRL": true,
+}
+
+// NewIbanCounterpartyReq builds the request adding the holder of an IBAN account as a counterparty. The bank
+// country and the currency are derived from the IBAN, and a UK IBAN is sent as its account number and sort code.
+// The currency is left empty for the countries whose currency is not known, set it before adding the counterparty.
+// A name ending with a legal form such as Ltd or GmbH, or made of a single word, is sent as a company name,
+// any other as the first and last name of an individual. The BIC is optional, though required for some
+// countries outside the eurozone. Fields can be adjusted on the returned request before AddNonRevolut.
+func NewIbanCounterpartyReq(iban, name, bic string) (*NonRevolutCounterpartyReq, error) {
+	iban = normaliseIban(iban)
+	if err := validateIban(iban); err != nil {
+		return nil, err
+	}
+
+	country := iban[:2]
+	currency := ibanCurrencies[country]
+	if eurozone[country] {
+		currency = "EUR"
+	}
+	r := &NonRevolutCounterpartyReq{
+		BankCountry: country,
+		Currency:    string(currency),
+		Bic:         strings.ToUpper(strings.TrimSpace(bic)),
+	}
+	if country == "GB" {
+		// GB, check digits, bank code of 4 letters, then the sort code and account number
+		r.SortCode, r.AccountNo = iban[8:14], iban[14:]
+	} else {
+		r.Iban = iban
+	}
+
+	if err := r.setHolder(name); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewUKCounterpartyReq builds the request adding the holder of a GBP account in the UK, identified by its
+// 8 digit account number and 6 digit sort code. The name is split as by NewIbanCounterpartyReq.
+func NewUKCounterpartyReq(name, accountNo, sortCode string) (*NonRevolutCounterpartyReq, error) {
+	r := &NonRevolutCounterpartyReq{
+		BankCountry: "GB",
+		Currency:    "GBP",
+		AccountNo:   normaliseDigits(accountNo),
+		SortCode:    normaliseDigits(sortCode),
+	}
+	if err := r.setHolder(name); err != nil {
+		return nil, err
+	}
+	return r, r.validateBankDetails()
+}
+
+// NewUSCounterpartyReq builds the request adding the holder of a USD account in the US, identified by its
+// account number and 9 digit ABA routing number. US payments need the address of the beneficiary.
+// The name is split as by NewIbanCounterpartyReq.
+func NewUSCounterpartyReq(name, accountNo, routingNumber string, address NonRevolutCounterpartyReqAddress) (*NonRevolutCounterpartyReq, error) {
+	if address.Country == "" {
+		address.Country = "US"
+	}
+	r := &NonRevolutCounterpartyReq{
+		BankCountry:   "US",
+		Currency:      "USD",
+		AccountNo:     normaliseDigits(accountNo),
+		RoutingNumber: normaliseDigits(routingNumber),
+		Address:       address,
+	}
+	if err := r.setHolder(name); err != nil {
+		return nil, err
+	}
+	return r, r.validateBankDetails()
+}
+
+// NewSEPACounterpartyReq builds the request adding the holder of a EUR account in the SEPA area, identified
+// by its IBAN and optional BIC. The name is split as by NewIbanCounterpartyReq.
+func NewSEPACounterpartyReq(name, iban, bic string) (*NonRevolutCounterpartyReq, error) {
+	iban = normaliseIban(iban)
+	if err := validateIban(iban); err != nil {
+		return nil, err
+	}
+	r := &NonRevolutCounterpartyReq{
+		BankCountry: iban[:2],
+		Currency:    "EUR",
+		Iban:        iban,
+		Bic:         strings.ToUpper(strings.TrimSpace(bic)),
+	}
+	if !sepa[r.BankCountry] {
+		return nil, invalid("iban", fmt.Sprintf("is not issued in a SEPA country, %s", r.BankCountry))
+	}
+	if err := r.setHolder(name); err != nil {
+		return nil, err
+	}
+	return r, r.validateBankDetails()
+}
+
+// setHolder sets the name of the holder: a company name when the name ends with a legal form such as Ltd or
+// GmbH or is a single word, the first and last name of an individual otherwise.
+func (r *NonRevolutCounterpartyReq) setHolder(name string) error {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return invalid("company_name", "or individual_name must be set")
+	}
+
+	last := strings.ToUpper(strings.Trim(words[len(words)-1], ".,"))
+	if len(words) == 1 || companySuffixes[strings.ReplaceAll(last, ".", "")] {
+		r.CompanyName = strings.Join(words, " ")
+		return nil
+	}
+	r.IndividualName = NonRevolutCounterpartyReqIndividualName{
+		FirstName: strings.Join(words[:len(words)-1], " "),
+		LastName:  words[len(words)-1],
+	}
+	return nil
+}
+
+// validateBankDetails checks the fields the bank country and currency require: the account number and sort code
+// of GBP accounts in the UK, the account and routing numbers and the address of USD accounts in the US, and the
+// IBAN of EUR accounts in the SEPA area.
+func (r *NonRevolutCounterpartyReq) validateBankDetails() error {
+	switch {
+	case r.BankCountry == "GB" && r.Currency == "GBP":
+		if !digits(r.AccountNo, 8, 8) {
+			return invalid("account_no", "must be 8 digits for a GBP account in the UK")
+		}
+		if !digits(r.SortCode, 6, 6) {
+			return invalid("sort_code", "must be 6 digits for a GBP account in the UK")
+		}
+		if r.Iban != "" || r.RoutingNumber != "" {
+			return invalid("iban", "and routing_number cannot be set for a GBP account in the UK")
+		}
+
+	case r.BankCountry == "US" && r.Currency == "USD":
+		if !digits(r.AccountNo, 4, 17) {
+			return invalid("account_no", "must be 4 to 17 digits for a USD account in the US")
+		}
+		if !validRoutingNumber(r.RoutingNumber) {
+			return invalid("routing_number", "must be a 9 digit ABA routing number for a USD account in the US")
+		}
+		if r.Address.StreetLine1 == "" || r.Address.City == "" || r.Address.Postcode == "" {
+			return invalid("address", "must have a street line, city and postcode for a USD account in the US")
+		}
+		if r.Iban != "" || r.SortCode != "" {
+			return invalid("iban", "and sort_code cannot be set for a USD account in the US")
+		}
+
+	case sepa[r.BankCountry] && r.Currency == "EUR":
+		if r.Iban == "" {
+			return invalid("iban", "is required for a EUR account in the SEPA area")
+		}
+		if err := validateIban(normaliseIban(r.Iban)); err != nil {
+			return err
+		}
+		if r.Bic != "" && !bicCode.MatchString(r.Bic) {
+			return invalid("bic", "must be 8 or 11 characters")
+		}
+	}
+	return nil
+}
+
+var bicCode = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// digits reports whether s is made of min to max digits.
+func digits(s string, min, max int) bool {
+	if len(s) < min || len(s) > max {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validRoutingNumber checks the length and the check digit of an ABA routing number.
+func validRoutingNumber(n string) bool {
+	if !digits(n, 9, 9) {
+		return false
+	}
+	sum := 0
+	for i, weight := range []int{3, 7, 1, 3, 7, 1, 3, 7, 1} {
+		sum += int(n[i]-'0') * weight
+	}
+	return sum%10 == 0
+}
+
+// validateIban checks the country, length and check digits of a normalised IBAN.
+func validateIban(iban string) error {
+	if len(iban) < 15 || len(iban) > 34 || !countryCode.MatchString(iban[:2]) {
+		return invalid("iban", "is not a valid IBAN")
+	}
+	if country := iban[:2]; country == "GB" && len(iban) != 22 {
+		return invalid("iban", "is not a valid UK IBAN")
+	}
+
+	// move the country and check digits to the end, letters count as 10 to 35, the number mod 97 must be 1
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return invalid("iban", "is not a valid IBAN")
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return invalid("iban", "has invalid check digits")
+	}
+	return nil
+}
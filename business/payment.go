@@ -0,0 +1,567 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type PaymentService struct {
+	accessToken string
+	sandbox     bool
+	options     *request.Options
+	events      *EventBus
+}
+
+type PaymentReq struct {
+	// the client provided ID of the transaction (40 characters max)
+	RequestId string `json:"request_id"`
+	// the ID of the account to pay from
+	AccountId string          `json:"account_id"`
+	Receiver  PaymentReceiver `json:"receiver"`
+	// the transaction amount
+	Amount float64 `json:"amount"`
+	// the transaction currency
+	Currency string `json:"currency"`
+	// an optional textual reference shown on the transaction
+	Reference string `json:"reference,omitempty"`
+	// a future date/time
+	// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-schedule-payment
+	ScheduleFor string `json:"schedule_for,omitempty"`
+	// an optional party paying the transfer fees of international payments, shared by default
+	ChargeBearer ChargeBearer `json:"charge_bearer,omitempty"`
+	// the reason code of the transfer, required for some countries and currencies, see TransferService.Reasons
+	TransferReasonCode string `json:"transfer_reason_code,omitempty"`
+}
+
+type ChargeBearer string
+
+const (
+	ChargeBearer_SHARED ChargeBearer = "shared"
+	ChargeBearer_DEBTOR ChargeBearer = "debtor"
+)
+
+type PaymentReceiver struct {
+	// the ID of the receiving counterparty
+	CounterpartyId string `json:"counterparty_id"`
+	// the ID of the receiving counterparty's account, provide only for payments to business counterparties,
+	//can be own account (only for internal counterparties)
+	AccountId string `json:"account_id,omitempty"`
+	// the ID of the receiving counterparty's card, provide only for payments to card counterparties
+	CardId string `json:"card_id,omitempty"`
+}
+
+// PaymentState is the state of a transaction. States unknown to this version of the library decode as is,
+// so check Known before switching exhaustively over the constants.
+type PaymentState string
+
+const (
+	PaymentState_PENDING  PaymentState = "pending"
+	PaymentState_COMPLETE PaymentState = "completed"
+	PaymentState_DECLINE  PaymentState = "declined"
+	PaymentState_FAILED   PaymentState = "failed"
+	PaymentState_REVERTED PaymentState = "reverted"
+	// the payment waits for an approver of the business, on accounts whose payments require approval
+	PaymentState_PENDING_APPROVAL PaymentState = "pending_approval"
+)
+
+// Known reports whether the state is one of the constants.
+func (s PaymentState) Known() bool {
+	switch s {
+	case PaymentState_PENDING, PaymentState_COMPLETE, PaymentState_DECLINE, PaymentState_FAILED, PaymentState_REVERTED,
+		PaymentState_PENDING_APPROVAL:
+		return true
+	}
+	return false
+}
+
+// Terminal reports whether the state is final: completed, declined, failed or reverted.
+func (s PaymentState) Terminal() bool {
+	return s == PaymentState_COMPLETE || s == PaymentState_DECLINE || s == PaymentState_FAILED || s == PaymentState_REVERTED
+}
+
+// ReasonCode explains why a transaction was declined or failed. Like PaymentState, unknown codes decode as is.
+type ReasonCode string
+
+const (
+	ReasonCode_INSUFFICIENT_BALANCE  ReasonCode = "insufficient_balance"
+	ReasonCode_INVALID_COUNTERPARTY  ReasonCode = "invalid_counterparty"
+	ReasonCode_COUNTERPARTY_DELETED  ReasonCode = "counterparty_deleted"
+	ReasonCode_ACCOUNT_INACTIVE      ReasonCode = "account_inactive"
+	ReasonCode_LIMIT_EXCEEDED        ReasonCode = "limit_exceeded"
+	ReasonCode_DECLINED_BY_RECIPIENT ReasonCode = "declined_by_recipient"
+	ReasonCode_EXPIRED               ReasonCode = "expired"
+	ReasonCode_CANCELLED             ReasonCode = "cancelled"
+	ReasonCode_RATE_CHANGED          ReasonCode = "rate_changed"
+)
+
+// Known reports whether the reason code is one of the constants.
+func (c ReasonCode) Known() bool {
+	switch c {
+	case ReasonCode_INSUFFICIENT_BALANCE, ReasonCode_INVALID_COUNTERPARTY, ReasonCode_COUNTERPARTY_DELETED,
+		ReasonCode_ACCOUNT_INACTIVE, ReasonCode_LIMIT_EXCEEDED, ReasonCode_DECLINED_BY_RECIPIENT,
+		ReasonCode_EXPIRED, ReasonCode_CANCELLED, ReasonCode_RATE_CHANGED:
+		return true
+	}
+	return false
+}
+
+type PaymentType string
+
+const (
+	PaymentType_ATM             PaymentType = "atm"
+	PaymentType_CARD_PAYMENT    PaymentType = "card_payment"
+	PaymentType_CARD_REFUND     PaymentType = "card_refund"
+	PaymentType_CARD_CHARGEBACK PaymentType = "card_chargeback"
+	PaymentType_CARD_CREDIT     PaymentType = "card_credit"
+	PaymentType_EXCHANGE        PaymentType = "exchange"
+	PaymentType_TRANSFER        PaymentType = "transfer"
+	PaymentType_LOAN            PaymentType = "loan"
+	PaymentType_FEE             PaymentType = "fee"
+	PaymentType_REFUND          PaymentType = "refund"
+	PaymentType_TOPUP           PaymentType = "topup"
+	PaymentType_TOPUP_RETURN    PaymentType = "topup_return"
+	PaymentType_TAX             PaymentType = "tax"
+	PaymentType_TAX_REFUND      PaymentType = "tax_refund"
+)
+
+type TransactionResp struct {
+	// the ID of transaction
+	Id string `json:"id"`
+	// the transaction type, one of atm, card_payment, card_refund, card_chargeback,
+	// card_credit, exchange, transfer, loan, fee, refund, topup, topup_return, tax, tax_refund
+	Type PaymentType `json:"type"`
+	// the client provided request ID
+	RequestId string `json:"request_id,omitempty"`
+	// the transction state: pending, completed, declined, failed or reverted
+	State PaymentState `json:"state"`
+	// the instant when the transaction was created
+	CreatedAt Time `json:"created_at"`
+	// the instant when the transaction was last updated
+	UpdatedAt Time `json:"updated_at"`
+	// the instant when the transaction was completed, nil unless completed
+	CompletedAt *Time `json:"completed_at,omitempty"`
+	// an optional date when the transaction was scheduled for
+	ScheduledFor string `json:"scheduled_for"`
+	// a user provided payment reference
+	Reference string `json:"reference,omitempty"`
+	// the legs of transaction, there'll be 2 legs between your Revolut accounts and 1 leg in other cases
+	Legs []TransactionLeg `json:"legs"`
+	// reason code for declined or failed transaction state
+	ReasonCode ReasonCode `json:"reason_code,omitempty"`
+	// the merchant info, nil but for card transactions
+	Merchant *TransactionMerchant `json:"merchant,omitempty"`
+	// the card information, nil but for card transactions
+	Card *TransactionCard `json:"card,omitempty"`
+	// the ID of the original transaction which has been refunded (only for refunds)
+	RelatedTransactionId string `json:"related_transaction_id,omitempty"`
+}
+
+type TransactionLeg struct {
+	// the ID of the leg
+	LegId string `json:"leg_id"`
+	// the ID of the account the transaction is associated with
+	AccountId    string          `json:"account_id"`
+	Counterparty LegCounterparty `json:"counterparty"`
+	// the transaction amount
+	Amount float64 `json:"amount"`
+	// the fee charged on the leg, as a positive number, nil when none was charged
+	Fee *float64 `json:"fee,omitempty"`
+	// the transaction currency
+	Currency string `json:"currency"`
+	// the billing amount for cross-currency payments, nil for the others
+	BillAmount *float64 `json:"bill_amount,omitempty"`
+	// the billing currency for cross-currency payments
+	BillCurrency string `json:"bill_currency"`
+	// the transaction leg purpose
+	Description string `json:"description"`
+	// a total balance of the account the transaction is associated with, nil when not returned
+	Balance *float64 `json:"balance,omitempty"`
+	// the amounts as decoded, see AmountDecimal
+	exact legDecimals
+}
+
+type LegCounterparty struct {
+	// the counterparty ID
+	Id string `json:"id"`
+	// the type of account: self, revolut, external, decoded from account_type as well
+	Type CounterpartyType `json:"type"`
+	// the counterparty account ID
+	AccountId string `json:"account_id"`
+	// the counterparty card ID, for payments to card counterparties
+	CardId string `json:"card_id,omitempty"`
+}
+
+// UnmarshalJSON decodes the type of account from either type or account_type, the documented name.
+func (c *LegCounterparty) UnmarshalJSON(b []byte) error {
+	type plain LegCounterparty
+	raw := struct {
+		*plain
+		AccountType CounterpartyType `json:"account_type"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if c.Type == "" {
+		c.Type = raw.AccountType
+	}
+	return nil
+}
+
+// Leg returns the leg of the transaction on the account, or nil if the transaction does not touch it.
+func (t *TransactionResp) Leg(accountId string) *TransactionLeg {
+	for i := range t.Legs {
+		if t.Legs[i].AccountId == accountId {
+			return &t.Legs[i]
+		}
+	}
+	return nil
+}
+
+type TransactionMerchant struct {
+	// the merchant ID, when returned
+	Id string `json:"id,omitempty"`
+	// the merchant name
+	Name string `json:"name"`
+	// the merchant city
+	City string `json:"city"`
+	// the merchant category code
+	CategoryCode string `json:"category_code"`
+	// 3-letter ISO bankCountry code
+	Country string `json:"country"`
+}
+
+type TransactionCard struct {
+	// the ID of the card, when returned, see CardService
+	Id string `json:"id,omitempty"`
+	// the masked card number
+	CardNumber string `json:"card_number"`
+	// the cardholder's first name
+	FirstName string `json:"first_name"`
+	// the cardholder's last name
+	LastName string `json:"last_name"`
+	// the cardholder's phone number
+	Phone string `json:"phone"`
+}
+
+type TransactionReq struct {
+	// an optional timestamp to query from, filtering on the created_at field
+	From string
+	// an optional timestamp to query to, filtering on the created_at field. Default is now
+	To string
+	// an optional counterparty id
+	Counterparty string
+	// an optional number of records to return (1000 max, default is 100)
+	Count int32
+	// the transaction type, one of atm, card_payment, card_refund, card_chargeback, card_credit,
+	//exchange, transfer, loan, fee, refund, topup, topup_return, tax, tax_refund
+	Type PaymentType
+}
+
+// Create: This endpoint creates a new payment. If the payment is for another Revolut account,
+// business or personal, the transaction may be processed synchronously. Without a RequestId, one is generated and
+// returned on the transaction, paymentReq is not modified: set RequestId before the call to retry it safely
+// after an error.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-create-payment
+func (p *PaymentService) Create(ctx context.Context, paymentReq *PaymentReq) (*TransactionResp, error) {
+	// a missing request ID is generated on a copy, the request of the caller is left untouched
+	generated := paymentReq.RequestId == ""
+	if generated {
+		id, err := p.options.NewId()
+		if err != nil {
+			return nil, err
+		}
+		req := *paymentReq
+		req.RequestId = id
+		paymentReq = &req
+	}
+	if err := paymentReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:      "payment.create",
+		Method:         http.MethodPost,
+		Url:            endpoint(nil, "pay"),
+		AccessToken:    p.accessToken,
+		Sandbox:        p.sandbox,
+		Options:        p.options,
+		Context:        ctx,
+		Body:           paymentReq,
+		ContentType:    request.ContentType_APPLICATION_JSON,
+		IdempotencyKey: paymentReq.RequestId,
+		GeneratedKey:   generated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &TransactionResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+	if r.RequestId == "" {
+		r.RequestId = paymentReq.RequestId
+	}
+
+	if r.State == PaymentState_PENDING_APPROVAL {
+		p.events.Publish(ApprovalRequired{Transaction: r, Req: paymentReq})
+	}
+	return r, nil
+}
+
+// WithId: To retrieve a transaction by ID
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) WithId(ctx context.Context, id string) (*TransactionResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment.with_id",
+		Method:      http.MethodGet,
+		Url:         endpoint(nil, "transaction", id),
+		AccessToken: p.accessToken,
+		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &TransactionResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// WaitForCompletion: Polls the transaction with an exponential backoff, from 1 second up to 30 seconds between
+// polls, until it reaches a terminal state (completed, declined, failed or reverted) or the context is done.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) WaitForCompletion(ctx context.Context, id string) (*TransactionResp, error) {
+	return p.poll(ctx, id, func(tx *TransactionResp) bool {
+		return tx.State.Terminal()
+	})
+}
+
+// poll gets the transaction with an exponential backoff until done says so or the context is done
+func (p *PaymentService) poll(ctx context.Context, id string, done func(*TransactionResp) bool) (*TransactionResp, error) {
+	backoff := time.Second
+	for {
+		tx, err := p.WithId(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if done(tx) {
+			return tx, nil
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return tx, ctx.Err()
+		case <-t.C:
+		}
+		if backoff *= 2; backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+	}
+}
+
+// WithRequestId: To retrieve a transaction by request ID
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) WithRequestId(ctx context.Context, requestId string) (*TransactionResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment.with_request_id",
+		Method:      http.MethodGet,
+		Url:         endpoint(url.Values{"id_type": []string{"request_id"}}, "transaction", requestId),
+		AccessToken: p.accessToken,
+		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &TransactionResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Cancel: This endpoint allows to cancel a scheduled transaction that was initiated by you, via API.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) Cancel(ctx context.Context, id string) error {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment.cancel",
+		Method:      http.MethodDelete,
+		Url:         endpoint(nil, "transaction", id),
+		AccessToken: p.accessToken,
+		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusNoContent {
+		return errors.New(string(resp))
+	}
+
+	return nil
+}
+
+// List: This endpoint retrieves historical transactions based on the provided query criteria.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) List(ctx context.Context, transactionReq *TransactionReq) ([]*TransactionResp, error) {
+	if err := transactionReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment.list",
+		Method:      http.MethodGet,
+		Url:         endpoint(transactionReq.params(), "transactions"),
+		AccessToken: p.accessToken,
+		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := []*TransactionResp{}
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ListEach: Like List, but decodes the transactions one at a time and hands each to fn instead of
+// holding the whole response in memory. Returning an error from fn stops the iteration and is returned.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) ListEach(ctx context.Context, transactionReq *TransactionReq, fn func(*TransactionResp) error) error {
+	if err := transactionReq.Validate(); err != nil {
+		return err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment.list",
+		Method:      http.MethodGet,
+		Url:         endpoint(transactionReq.params(), "transactions"),
+		AccessToken: p.accessToken,
+		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
+		Stream: func(body io.Reader) error {
+			return decodeEach(body, func(dec *json.Decoder) error {
+				r := &TransactionResp{}
+				if err := dec.Decode(r); err != nil {
+					return err
+				}
+				return fn(r)
+			})
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return errors.New(string(resp))
+	}
+
+	return nil
+}
+
+func (t *TransactionReq) params() url.Values {
+	params := url.Values{}
+	if t.From != "" {
+		params.Add("from", t.From)
+	}
+	if t.To != "" {
+		params.Add("to", t.To)
+	}
+	if t.Counterparty != "" {
+		params.Add("counterparty", t.Counterparty)
+	}
+	if t.Count != 0 {
+		params.Add("count", fmt.Sprintf("%d", t.Count))
+	}
+	if t.Type != "" {
+		params.Add("type", string(t.Type))
+	}
+
+	return params
+}
+
+// maxTransactionCount is the largest page of transactions the API returns
+const maxTransactionCount = 1000
+
+// ListAll: Iterates over every transaction matching the criteria, newest first. Pages of transactionReq.Count
+// (1000 by default) transactions are fetched, each one up to the creation instant of the oldest transaction
+// of the previous page, until the list is exhausted.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#payments-get-transaction
+func (p *PaymentService) ListAll(ctx context.Context, transactionReq *TransactionReq) *Pager[*TransactionResp] {
+	req := *transactionReq
+	if req.Count == 0 {
+		req.Count = maxTransactionCount
+	}
+
+	// transactions created at the cursor instant are returned again on the next page
+	boundary := map[string]bool{}
+	return newPager(func(cursor string) ([]*TransactionResp, string, error) {
+		if cursor != "" {
+			req.To = cursor
+		}
+
+		page, err := p.List(ctx, &req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		fresh := make([]*TransactionResp, 0, len(page))
+		for _, tx := range page {
+			if !boundary[tx.Id] {
+				fresh = append(fresh, tx)
+			}
+		}
+		if len(page) < int(req.Count) || len(fresh) == 0 {
+			return fresh, "", nil
+		}
+
+		oldest := page[len(page)-1].CreatedAt.Time
+		boundary = map[string]bool{}
+		for _, tx := range page {
+			if tx.CreatedAt.Equal(oldest) {
+				boundary[tx.Id] = true
+			}
+		}
+
+		return fresh, oldest.Format(time.RFC3339Nano), nil
+	})
+}
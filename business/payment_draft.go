@@ -1,19 +1,18 @@
 package business
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 
-	"github.com/quiver-london/go-revolut/business/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/business/request"
 )
 
 type PaymentDraftService struct {
 	accessToken string
 	sandbox     bool
-
-	err error
+	options     *request.Options
 }
 
 type PaymentDraftReq struct {
@@ -107,16 +106,19 @@ type PaymentDraftDetailPayment struct {
 
 // Create:
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#payment-drafts-create-a-payment-draft
-func (e *PaymentDraftService) Create(paymentDraftReq *PaymentDraftReq) (*PaymentDraftResp, error) {
-	if e.err != nil {
-		return nil, e.err
+func (e *PaymentDraftService) Create(ctx context.Context, paymentDraftReq *PaymentDraftReq) (*PaymentDraftResp, error) {
+	if err := paymentDraftReq.Validate(); err != nil {
+		return nil, err
 	}
 
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment_draft.create",
 		Method:      http.MethodPost,
-		Url:         "https://b2b.revolut.com/api/1.0/payment-drafts",
+		Url:         endpoint(nil, "payment-drafts"),
 		AccessToken: e.accessToken,
 		Sandbox:     e.sandbox,
+		Options:     e.options,
+		Context:     ctx,
 		Body:        paymentDraftReq,
 		ContentType: request.ContentType_APPLICATION_JSON,
 	})
@@ -137,16 +139,15 @@ func (e *PaymentDraftService) Create(paymentDraftReq *PaymentDraftReq) (*Payment
 
 // List:
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#get-payment-drafts
-func (e *PaymentDraftService) List() (*PaymentDrafts, error) {
-	if e.err != nil {
-		return nil, e.err
-	}
-
+func (e *PaymentDraftService) List(ctx context.Context) (*PaymentDrafts, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment_draft.list",
 		Method:      http.MethodGet,
-		Url:         "https://b2b.revolut.com/api/1.0/payment-drafts",
+		Url:         endpoint(nil, "payment-drafts"),
 		AccessToken: e.accessToken,
 		Sandbox:     e.sandbox,
+		Options:     e.options,
+		Context:     ctx,
 	})
 	if err != nil {
 		return nil, err
@@ -165,16 +166,15 @@ func (e *PaymentDraftService) List() (*PaymentDrafts, error) {
 
 // WithId:
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#get-payment-drafts-get-payment-draft-by-id
-func (e *PaymentDraftService) WithId(id string) (*PaymentDraftDetailPayment, error) {
-	if e.err != nil {
-		return nil, e.err
-	}
-
+func (e *PaymentDraftService) WithId(ctx context.Context, id string) (*PaymentDraftDetailPayment, error) {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment_draft.with_id",
 		Method:      http.MethodGet,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/payment-drafts/%s", id),
+		Url:         endpoint(nil, "payment-drafts", id),
 		AccessToken: e.accessToken,
 		Sandbox:     e.sandbox,
+		Options:     e.options,
+		Context:     ctx,
 	})
 	if err != nil {
 		return nil, err
@@ -193,16 +193,15 @@ func (e *PaymentDraftService) WithId(id string) (*PaymentDraftDetailPayment, err
 
 // Delete:
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#get-payment-drafts-delete-payment-draft
-func (e *PaymentDraftService) Delete(id string) error {
-	if e.err != nil {
-		return e.err
-	}
-
+func (e *PaymentDraftService) Delete(ctx context.Context, id string) error {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "payment_draft.delete",
 		Method:      http.MethodDelete,
-		Url:         fmt.Sprintf("https://b2b.revolut.com/api/1.0/payment-drafts/%s", id),
+		Url:         endpoint(nil, "payment-drafts", id),
 		AccessToken: e.accessToken,
 		Sandbox:     e.sandbox,
+		Options:     e.options,
+		Context:     ctx,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,127 @@
+package business_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+// TestGeneratedRequestId checks a generated request ID is returned on the response and not written to the
+// request of the caller, and that reusing it does not create a second transaction.
+func TestGeneratedRequestId(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts: []*business.AccountResp{
+			{Id: "gbp", Currency: "GBP", Balance: 1000},
+			{Id: "eur", Currency: "EUR", Balance: 1000},
+		},
+		Counterparties: []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+		Rates:          map[string]float64{"GBP/EUR": 1.17},
+	})
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	t.Run("payment", func(t *testing.T) {
+		p, err := c.Payment()
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &business.PaymentReq{AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: 10, Currency: "GBP"}
+		tx, err := p.Create(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.RequestId != "" {
+			t.Errorf("got request ID %q on the request, want it untouched", req.RequestId)
+		}
+		if tx.RequestId == "" {
+			t.Fatal("got no request ID on the transaction")
+		}
+
+		retry := *req
+		retry.RequestId = tx.RequestId
+		again, err := p.Create(ctx, &retry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.Id != tx.Id {
+			t.Errorf("got transaction %s on retry, want %s", again.Id, tx.Id)
+		}
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		e, err := c.Exchange()
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &business.ExchangeReq{
+			From: business.ExchangeAmount{AccountId: "gbp", Currency: "GBP", Amount: 10},
+			To:   business.ExchangeAmount{AccountId: "eur", Currency: "EUR"},
+		}
+		r, err := e.Exchange(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.RequestId != "" {
+			t.Errorf("got request ID %q on the request, want it untouched", req.RequestId)
+		}
+		if r.RequestId == "" {
+			t.Fatal("got no request ID on the exchange")
+		}
+
+		retry := *req
+		retry.RequestId = r.RequestId
+		again, err := e.Exchange(ctx, &retry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.Id != r.Id {
+			t.Errorf("got exchange %s on retry, want %s", again.Id, r.Id)
+		}
+	})
+}
+
+func TestWaitForCompletionReverted(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts:       []*business.AccountResp{{Id: "gbp", Currency: "GBP", Balance: 1000}},
+		Counterparties: []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+	})
+	defer s.Close()
+	c, err := s.SandboxClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.Payment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := p.Create(ctx, &business.PaymentReq{AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: 10, Currency: "GBP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sandbox.Simulate(ctx, tx.Id, business.SimulationAction_REVERT); err != nil {
+		t.Fatal(err)
+	}
+
+	// a reverted payment is terminal, the first poll returns it
+	got, err := p.WaitForCompletion(ctx, tx.Id)
+	if err != nil || got.State != business.PaymentState_REVERTED {
+		t.Errorf("got %v, %v, want the payment reverted", got, err)
+	}
+	if n := s.Calls("GET /transaction"); n != 1 {
+		t.Errorf("got %d polls, want 1", n)
+	}
+}
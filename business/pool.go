@@ -0,0 +1,159 @@
+package business
+
+import (
+	"crypto"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Credentials are what a client of one entity is built from.
+type Credentials struct {
+	ClientId     string
+	RefreshToken string
+	PrivateKey   crypto.Signer
+	Issuer       string
+	Sandbox      bool
+	// options of this entity only, applied after the options of the pool, e.g. WithTokenStore keyed
+	// by the entity
+	Options []Option
+}
+
+// CredentialsProvider looks up the credentials of an entity, e.g. in a database of onboarded customers.
+type CredentialsProvider interface {
+	Credentials(id string) (*Credentials, error)
+}
+
+// CredentialsProviderFunc adapts an ordinary function to the CredentialsProvider interface.
+type CredentialsProviderFunc func(id string) (*Credentials, error)
+
+func (f CredentialsProviderFunc) Credentials(id string) (*Credentials, error) {
+	return f(id)
+}
+
+// PoolOption configures optional behaviour of a ClientPool.
+type PoolOption func(*ClientPool)
+
+// WithCredentialsProvider makes the pool build the client of an entity on first use from the credentials provided.
+func WithCredentialsProvider(provider CredentialsProvider) PoolOption {
+	return func(p *ClientPool) {
+		p.provider = provider
+	}
+}
+
+// WithClientOptions applies the options to every client the pool builds. Options creating state, such as
+// WithRateLimit, give every entity its own, so one tenant cannot exhaust the rate limit of the others;
+// WithRateLimiter shares a limiter across all of them.
+func WithClientOptions(opts ...Option) PoolOption {
+	return func(p *ClientPool) {
+		p.opts = append(p.opts, opts...)
+	}
+}
+
+// ClientPool holds the clients of several Revolut Business entities keyed by an ID of your choice,
+// e.g. the business or customer ID of your platform. Every client refreshes its own tokens.
+// It is safe for concurrent use.
+type ClientPool struct {
+	provider CredentialsProvider
+	opts     []Option
+
+	mu      sync.RWMutex
+	clients map[string]*poolEntry
+}
+
+// poolEntry builds a client once, concurrent Gets of the same entity wait for the same build
+type poolEntry struct {
+	once   sync.Once
+	client *Client
+	err    error
+}
+
+func NewClientPool(opts ...PoolOption) *ClientPool {
+	p := &ClientPool{clients: map[string]*poolEntry{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Add registers the client under the ID, replacing any client registered before.
+func (p *ClientPool) Add(id string, c *Client) {
+	e := &poolEntry{client: c}
+	e.once.Do(func() {})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clients[id] = e
+}
+
+// Remove forgets the client registered under the ID, e.g. when off-boarding a customer.
+func (p *ClientPool) Remove(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, id)
+}
+
+// IDs returns the IDs of the clients in the pool, sorted.
+func (p *ClientPool) IDs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ids := make([]string, 0, len(p.clients))
+	for id := range p.clients {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Get returns the client registered under the ID. Without one, the client is built from the credentials
+// provider, if any; a failed build is not cached and is retried on the next Get.
+func (p *ClientPool) Get(id string) (*Client, error) {
+	p.mu.RLock()
+	e, ok := p.clients[id]
+	p.mu.RUnlock()
+
+	if !ok {
+		if p.provider == nil {
+			return nil, fmt.Errorf("pool: no client registered for %q", id)
+		}
+
+		p.mu.Lock()
+		if e, ok = p.clients[id]; !ok {
+			e = &poolEntry{}
+			p.clients[id] = e
+		}
+		p.mu.Unlock()
+	}
+
+	e.once.Do(func() {
+		e.client, e.err = p.build(id)
+	})
+	if e.err != nil {
+		p.mu.Lock()
+		if p.clients[id] == e {
+			delete(p.clients, id)
+		}
+		p.mu.Unlock()
+		return nil, e.err
+	}
+
+	return e.client, nil
+}
+
+func (p *ClientPool) build(id string) (*Client, error) {
+	creds, err := p.provider.Credentials(id)
+	if err != nil {
+		return nil, fmt.Errorf("pool: credentials of %q: %w", id, err)
+	}
+
+	opts := append(append([]Option{}, p.opts...), creds.Options...)
+	c, err := NewClient(creds.ClientId, creds.RefreshToken, creds.PrivateKey, creds.Issuer, creds.Sandbox, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("pool: client of %q: %w", id, err)
+	}
+
+	return c, nil
+}
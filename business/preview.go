@@ -0,0 +1,181 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CostPreview is the breakdown of a planned payment or exchange, meant for confirmation screens.
+// Nothing is executed to build it, the figures come from the rate endpoint at the time of the call.
+type CostPreview struct {
+	// the amount leaving the source account, before fees
+	Send Amount
+	// the amount expected to reach the receiver or the target account
+	Receive Amount
+	// the expected exchange rate, 1 when no conversion takes place
+	Rate float64
+	// date of the quoted exchange rate, zero when no conversion takes place
+	RateDate time.Time
+	// the fee charged for the conversion, in the currency Revolut charges it in, see FxFee.Currency: a fee in the
+	// source currency is part of TotalDebit, one in the target currency is taken from the amount received and is
+	// not converted
+	FxFee Amount
+	// the fee of the payment scheme, nil as long as the API does not quote it
+	SchemeFee *Amount
+	// the total debited from the source account, fees included
+	TotalDebit Amount
+	// the receiving counterparty of a payment, nil for an exchange or when it could not be read
+	Receiver *CounterpartyResp
+	// the auxiliary lookups that failed while building the preview
+	Warnings Warnings
+}
+
+// CostPlan is a planned operation PreviewCost can price, either a *PaymentReq or an *ExchangeReq.
+type CostPlan interface {
+	previewCost(ctx context.Context, b *Client) (*CostPreview, error)
+}
+
+// PreviewCost returns the expected cost of the planned payment or exchange without executing it.
+func (b *Client) PreviewCost(ctx context.Context, plan CostPlan) (*CostPreview, error) {
+	return plan.previewCost(ctx, b)
+}
+
+func (p *PaymentReq) previewCost(ctx context.Context, b *Client) (*CostPreview, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	accounts, err := b.Account()
+	if err != nil {
+		return nil, err
+	}
+	account, err := accounts.WithId(ctx, p.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := b.quote(ctx, account.Currency, p.Currency, 0, p.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// the receiver is only shown on the confirmation screen, the cost holds without it
+	counterparties, err := b.Counterparty()
+	if err == nil {
+		preview.Receiver, err = counterparties.WithId(ctx, p.Receiver.CounterpartyId)
+	}
+	if err != nil {
+		preview.Warnings.Add("counterparty.with_id", "receiver", err)
+	}
+
+	return preview, nil
+}
+
+func (e *ExchangeReq) previewCost(ctx context.Context, b *Client) (*CostPreview, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.quote(ctx, string(e.From.Currency), string(e.To.Currency), e.From.Amount, e.To.Amount)
+}
+
+// ExchangeQuote is the expected outcome of an exchange, to show before confirming it.
+type ExchangeQuote struct {
+	// the amount exchanged from the source account, before the fee
+	Send Amount `json:"send"`
+	// the amount expected on the target account
+	Receive Amount `json:"receive"`
+	// the fee of the exchange
+	Fee Amount `json:"fee"`
+	// the total debited from the source account, the fee included when charged in the source currency
+	TotalDebit Amount `json:"total_debit"`
+	// the quoted exchange rate, in target per source currency
+	Rate float64 `json:"rate"`
+	// the rate once the fee is paid, the amount received for the total debited
+	EffectiveRate float64 `json:"effective_rate"`
+	// date of the quoted exchange rate, zero when no conversion takes place
+	RateDate time.Time `json:"rate_date"`
+}
+
+// QuoteExchange returns the expected amount received, fee and effective rate of the exchange from the rate
+// endpoint, without executing it. Either amount of the request is priced, like PreviewCost does; the quote holds
+// as long as the rate does.
+func (b *Client) QuoteExchange(ctx context.Context, exchangeReq *ExchangeReq) (*ExchangeQuote, error) {
+	preview, err := b.PreviewCost(ctx, exchangeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &ExchangeQuote{
+		Send:       preview.Send,
+		Receive:    preview.Receive,
+		Fee:        preview.FxFee,
+		TotalDebit: preview.TotalDebit,
+		Rate:       preview.Rate,
+		RateDate:   preview.RateDate,
+	}
+	// a fee in the target currency is taken from the amount received
+	received := q.Receive.Amount
+	if q.Fee.Currency != q.Send.Currency && q.Fee.Currency == q.Receive.Currency {
+		received -= q.Fee.Amount
+	}
+	if q.TotalDebit.Amount > 0 {
+		q.EffectiveRate = received / q.TotalDebit.Amount
+	}
+	return q, nil
+}
+
+// quote prices the conversion from one currency to another, given either the amount sent or the amount received.
+func (b *Client) quote(ctx context.Context, from, to string, send, receive float64) (*CostPreview, error) {
+	if from == to {
+		amount := send + receive
+		return &CostPreview{
+			Send:       Amount{Amount: amount, Currency: from},
+			Receive:    Amount{Amount: amount, Currency: to},
+			Rate:       1,
+			FxFee:      Amount{Currency: from},
+			TotalDebit: Amount{Amount: amount, Currency: from},
+		}, nil
+	}
+
+	exchange, err := b.exchange()
+	if err != nil {
+		return nil, err
+	}
+
+	if send == 0 {
+		// the rate endpoint prices the amount sent, so the amount received is converted back first
+		r, err := exchange.Rate(ctx, &ExchangeRateReq{From: Currency(from), To: Currency(to), Amount: 1})
+		if err != nil {
+			return nil, err
+		}
+		if r.Rate == 0 {
+			return nil, fmt.Errorf("preview: no exchange rate from %s to %s", from, to)
+		}
+		send = exchange.round(ctx, "exchange.preview", receive/r.Rate, Currency(from).Decimals())
+	}
+
+	r, err := exchange.Rate(ctx, &ExchangeRateReq{From: Currency(from), To: Currency(to), Amount: send})
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &CostPreview{
+		Send:       Amount{Amount: send, Currency: from},
+		Receive:    r.To,
+		Rate:       r.Rate,
+		RateDate:   r.RateDate.Time,
+		FxFee:      r.Fee,
+		TotalDebit: Amount{Amount: send, Currency: from},
+	}
+	if receive != 0 {
+		preview.Receive = Amount{Amount: receive, Currency: to}
+	}
+	if r.Fee.Currency == "" || r.Fee.Currency == from {
+		preview.FxFee.Currency = from
+		preview.TotalDebit.Amount = exchange.round(ctx, "exchange.preview", send+r.Fee.Amount, Currency(from).Decimals())
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,221 @@
+package business
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProfilesEnv names the environment variable holding the path of the profiles file read by NewClientFromProfile.
+const ProfilesEnv = "REVOLUT_PROFILES"
+
+// Profile holds the credentials of one Revolut Business API certificate, e.g. the production or the
+// sandbox one of an application managing both.
+type Profile struct {
+	// the name the profile is looked up by, e.g. "prod"
+	Name     string `json:"name"`
+	ClientId string `json:"client_id"`
+	// the path of the PEM private key of the certificate
+	PrivateKeyPath string `json:"private_key_path"`
+	Sandbox        bool   `json:"sandbox"`
+	// the OAuth redirect URI of the certificate
+	RedirectUri string `json:"redirect_uri"`
+	// the JWT issuer, the host of the redirect URI when empty
+	Issuer string `json:"issuer,omitempty"`
+	// the refresh token obtained on consent, better set from the environment than kept in a file
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// Profiles are profiles keyed by name.
+type Profiles map[string]*Profile
+
+// LoadProfiles reads a JSON file of profiles keyed by name:
+//
+//	{
+//	  "prod": {"client_id": "...", "private_key_path": "prod.pem", "redirect_uri": "https://example.com/revolut"},
+//	  "sandbox": {"client_id": "...", "private_key_path": "sandbox.pem", "redirect_uri": "https://example.com/revolut", "sandbox": true}
+//	}
+func LoadProfiles(path string) (Profiles, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := Profiles{}
+	if err := json.Unmarshal(b, &ps); err != nil {
+		return nil, fmt.Errorf("profile: %s: %w", path, err)
+	}
+	for name, p := range ps {
+		p.Name = name
+	}
+	return ps, nil
+}
+
+// the variables of a profile set in the environment, REVOLUT_<NAME><suffix>
+var profileEnvSuffixes = []string{"_CLIENT_ID", "_PRIVATE_KEY_FILE", "_SANDBOX", "_REDIRECT_URI", "_ISSUER", "_REFRESH_TOKEN"}
+
+// ProfilesFromEnv reads the profiles set in the environment with the variables REVOLUT_<NAME>_CLIENT_ID,
+// REVOLUT_<NAME>_PRIVATE_KEY_FILE, REVOLUT_<NAME>_SANDBOX, REVOLUT_<NAME>_REDIRECT_URI, REVOLUT_<NAME>_ISSUER and
+// REVOLUT_<NAME>_REFRESH_TOKEN. A profile may set only some of them, e.g. the refresh token of a profile of the
+// file read by NewClientFromProfile. Profile names are lower case.
+func ProfilesFromEnv() Profiles {
+	ps := Profiles{}
+	for _, kv := range os.Environ() {
+		key := strings.SplitN(kv, "=", 2)[0]
+		prefix := key
+		for _, suffix := range profileEnvSuffixes {
+			if strings.HasSuffix(key, suffix) {
+				prefix = strings.TrimSuffix(key, suffix)
+				break
+			}
+		}
+		if prefix == key || !strings.HasPrefix(prefix, "REVOLUT_") {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(prefix, "REVOLUT_"))
+		if _, ok := ps[name]; ok {
+			continue
+		}
+		sandbox, _ := strconv.ParseBool(os.Getenv(prefix + "_SANDBOX"))
+		ps[name] = &Profile{
+			Name:           name,
+			ClientId:       os.Getenv(prefix + "_CLIENT_ID"),
+			PrivateKeyPath: os.Getenv(prefix + "_PRIVATE_KEY_FILE"),
+			Sandbox:        sandbox,
+			RedirectUri:    os.Getenv(prefix + "_REDIRECT_URI"),
+			Issuer:         os.Getenv(prefix + "_ISSUER"),
+			RefreshToken:   os.Getenv(prefix + "_REFRESH_TOKEN"),
+		}
+	}
+	return ps
+}
+
+// Merge returns the profiles of both, the fields set in other overriding those of ps.
+func (ps Profiles) Merge(other Profiles) Profiles {
+	merged := Profiles{}
+	for name, p := range ps {
+		c := *p
+		merged[name] = &c
+	}
+	for name, o := range other {
+		p, ok := merged[name]
+		if !ok {
+			c := *o
+			merged[name] = &c
+			continue
+		}
+		p.merge(o)
+	}
+	return merged
+}
+
+func (p *Profile) merge(o *Profile) {
+	for _, f := range []struct{ dst, src *string }{
+		{&p.ClientId, &o.ClientId},
+		{&p.PrivateKeyPath, &o.PrivateKeyPath},
+		{&p.RedirectUri, &o.RedirectUri},
+		{&p.Issuer, &o.Issuer},
+		{&p.RefreshToken, &o.RefreshToken},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	p.Sandbox = p.Sandbox || o.Sandbox
+}
+
+// Names returns the names of the profiles, sorted.
+func (ps Profiles) Names() []string {
+	names := make([]string, 0, len(ps))
+	for name := range ps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Client returns a client built from the profile of the name.
+func (ps Profiles) Client(name string, opts ...Option) (*Client, error) {
+	p, ok := ps[name]
+	if !ok {
+		return nil, fmt.Errorf("profile: no profile named %q", name)
+	}
+	return p.Client(opts...)
+}
+
+// NewClientFromProfile returns a client built from the profile of the name, read from the file at the path
+// held by REVOLUT_PROFILES, if set, and from the environment, which overrides the file:
+//
+//	bC, err := business.NewClientFromProfile("prod")
+func NewClientFromProfile(name string, opts ...Option) (*Client, error) {
+	ps := Profiles{}
+	if path := os.Getenv(ProfilesEnv); path != "" {
+		var err error
+		if ps, err = LoadProfiles(path); err != nil {
+			return nil, err
+		}
+	}
+	return ps.Merge(ProfilesFromEnv()).Client(name, opts...)
+}
+
+// Client returns a client built from the profile. It needs a refresh token, see OAuth to obtain one.
+func (p *Profile) Client(opts ...Option) (*Client, error) {
+	if p.RefreshToken == "" {
+		return nil, fmt.Errorf("profile: %s has no refresh token", p.Name)
+	}
+	key, issuer, err := p.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(p.ClientId, p.RefreshToken, key, issuer, p.Sandbox, opts...)
+}
+
+// OAuth returns the OAuth service of the profile, to build its consent URL with AuthorizationURL
+// and exchange the code for a refresh token.
+func (p *Profile) OAuth() (*OAuthService, error) {
+	key, issuer, err := p.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return NewOAuth(p.ClientId, key, issuer, p.Sandbox), nil
+}
+
+// AuthorizationURL returns the consent URL of the profile, redirecting to its redirect URI.
+func (p *Profile) AuthorizationURL(state string) (string, error) {
+	oa, err := p.OAuth()
+	if err != nil {
+		return "", err
+	}
+	return oa.AuthorizationURL(p.RedirectUri, state)
+}
+
+func (p *Profile) credentials() (crypto.Signer, string, error) {
+	switch {
+	case p.ClientId == "":
+		return nil, "", fmt.Errorf("profile: %s has no client id", p.Name)
+	case p.PrivateKeyPath == "":
+		return nil, "", fmt.Errorf("profile: %s has no private key path", p.Name)
+	}
+
+	issuer := p.Issuer
+	if issuer == "" {
+		u, err := url.Parse(p.RedirectUri)
+		if err != nil || u.Host == "" {
+			return nil, "", fmt.Errorf("profile: %s has no issuer nor a redirect uri to derive it from", p.Name)
+		}
+		issuer = u.Hostname()
+	}
+
+	key, err := LoadPrivateKeyFromFile(p.PrivateKeyPath, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("profile: %s: %w", p.Name, err)
+	}
+	return key, issuer, nil
+}
@@ -0,0 +1,110 @@
+package business
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateCache keeps exchange rates for a while, so identical Rate queries don't each cost an API call.
+// It is safe for concurrent use.
+type RateCache struct {
+	ttl      time.Duration
+	maxPairs int
+
+	mu      sync.Mutex
+	entries map[ExchangeRateReq]*rateEntry
+}
+
+type rateEntry struct {
+	rate    ExchangeRateResp
+	expires time.Time
+}
+
+// NewRateCache returns a cache keeping every rate for ttl, and at most maxPairs queries, a query being
+// the pair and the amount. The entry closest to expiry is evicted first. A maxPairs of zero or less means
+// no limit.
+func NewRateCache(ttl time.Duration, maxPairs int) *RateCache {
+	return &RateCache{
+		ttl:      ttl,
+		maxPairs: maxPairs,
+		entries:  map[ExchangeRateReq]*rateEntry{},
+	}
+}
+
+// WithRateCache answers the Rate queries of the client's exchange service from cache while they are fresh.
+// Exchanges are never cached.
+func WithRateCache(cache *RateCache) Option {
+	return func(c *Client) {
+		c.rateCache = cache
+	}
+}
+
+// Wrap returns an exchanger answering Rate from the cache and delegating misses and Exchange to e.
+func (c *RateCache) Wrap(e Exchanger) Exchanger {
+	return &cachedExchanger{Exchanger: e, cache: c}
+}
+
+// Purge drops every cached rate.
+func (c *RateCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[ExchangeRateReq]*rateEntry{}
+}
+
+func (c *RateCache) get(key ExchangeRateReq, now time.Time) (*ExchangeRateResp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	rate := e.rate
+	return &rate, true
+}
+
+func (c *RateCache) put(key ExchangeRateReq, rate *ExchangeRateResp, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && c.maxPairs > 0 && len(c.entries) >= c.maxPairs {
+		var oldest ExchangeRateReq
+		first := true
+		for k, e := range c.entries {
+			if first || e.expires.Before(c.entries[oldest].expires) {
+				oldest, first = k, false
+			}
+		}
+		delete(c.entries, oldest)
+	}
+
+	c.entries[key] = &rateEntry{rate: *rate, expires: now.Add(c.ttl)}
+}
+
+type cachedExchanger struct {
+	Exchanger
+	cache *RateCache
+}
+
+func (e *cachedExchanger) Rate(ctx context.Context, exchangeRateReq *ExchangeRateReq) (*ExchangeRateResp, error) {
+	now := time.Now()
+	if rate, ok := e.cache.get(*exchangeRateReq, now); ok {
+		return rate, nil
+	}
+
+	rate, err := e.Exchanger.Rate(ctx, exchangeRateReq)
+	if err != nil {
+		return nil, err
+	}
+	e.cache.put(*exchangeRateReq, rate, now)
+
+	r := *rate
+	return &r, nil
+}
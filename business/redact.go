@@ -0,0 +1,121 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// redacted replaces a secret in String and MarshalJSON output, an empty secret stays empty so its absence shows.
+func redacted(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// RawToken has the fields of Token without its redacting methods, for token stores persisting tokens as JSON.
+type RawToken Token
+
+// String never prints the access or refresh token, so tokens can be logged with %v and %+v.
+func (t Token) String() string {
+	return fmt.Sprintf("{AccessToken:%s RefreshToken:%s Expiry:%s ConsentExpiry:%s}",
+		redacted(t.AccessToken), redacted(t.RefreshToken), t.Expiry.Format(time.RFC3339), t.ConsentExpiry.Format(time.RFC3339))
+}
+
+func (t Token) GoString() string {
+	return "business.Token" + t.String()
+}
+
+// MarshalJSON redacts the access and refresh tokens. Marshal a *RawToken to persist a token.
+func (t Token) MarshalJSON() ([]byte, error) {
+	r := RawToken(t)
+	r.AccessToken, r.RefreshToken = redacted(t.AccessToken), redacted(t.RefreshToken)
+	return json.Marshal(&r)
+}
+
+func (r OAuthResp) String() string {
+	return fmt.Sprintf("{AccessToken:%s TokenType:%s ExpiresIn:%d RefreshToken:%s}",
+		redacted(r.AccessToken), r.TokenType, r.ExpiresIn, redacted(r.RefreshToken))
+}
+
+func (r OAuthResp) GoString() string {
+	return "business.OAuthResp" + r.String()
+}
+
+// RawCardSensitiveDetails has the fields of CardSensitiveDetailsResp without its redacting methods, to hand the
+// details on to a PCI compliant vault.
+type RawCardSensitiveDetails CardSensitiveDetailsResp
+
+// String never prints the card number, security code nor expiry date, so the details can be logged with %v and %+v.
+func (d CardSensitiveDetailsResp) String() string {
+	return fmt.Sprintf("{Pan:%s Cvv:%s Expiry:%s CardholderName:%s}",
+		redacted(d.Pan), redacted(d.Cvv), redacted(d.Expiry), d.CardholderName)
+}
+
+func (d CardSensitiveDetailsResp) GoString() string {
+	return "business.CardSensitiveDetailsResp" + d.String()
+}
+
+// MarshalJSON redacts the card number, security code and expiry date. Marshal a *RawCardSensitiveDetails to send
+// them on.
+func (d CardSensitiveDetailsResp) MarshalJSON() ([]byte, error) {
+	r := RawCardSensitiveDetails(d)
+	r.Pan, r.Cvv, r.Expiry = redacted(d.Pan), redacted(d.Cvv), redacted(d.Expiry)
+	return json.Marshal(&r)
+}
+
+// oauthConfig is the part of an OAuth configuration that is safe to print.
+type oauthConfig struct {
+	ClientId   string `json:"client_id"`
+	Issuer     string `json:"issuer"`
+	Sandbox    bool   `json:"sandbox"`
+	PrivateKey string `json:"private_key"`
+}
+
+func (oa *OAuthService) config() oauthConfig {
+	c := oauthConfig{ClientId: oa.clientId, Issuer: oa.issuer, Sandbox: oa.sandbox}
+	if oa.privateKey != nil {
+		c.PrivateKey = fmt.Sprintf("[REDACTED %T]", oa.privateKey)
+	}
+	return c
+}
+
+// String prints the configuration of the service, never its private key nor its cached client assertion.
+func (oa *OAuthService) String() string {
+	return fmt.Sprintf("%+v", oa.config())
+}
+
+func (oa *OAuthService) GoString() string {
+	return "business.OAuthService" + oa.String()
+}
+
+func (oa *OAuthService) MarshalJSON() ([]byte, error) {
+	return json.Marshal(oa.config())
+}
+
+// String prints the configuration of the client, never its private key or tokens.
+func (b *Client) String() string {
+	return fmt.Sprintf("%+v", b.config())
+}
+
+func (b *Client) GoString() string {
+	return "business.Client" + b.String()
+}
+
+func (b *Client) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.config())
+}
+
+// clientConfig is the part of a client configuration that is safe to print.
+type clientConfig struct {
+	oauthConfig
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (b *Client) config() clientConfig {
+	return clientConfig{
+		oauthConfig:  b.oa.config(),
+		RefreshToken: redacted(b.refreshToken),
+	}
+}
@@ -0,0 +1,55 @@
+package business_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+func TestRedact(t *testing.T) {
+	token := business.Token{AccessToken: "access-secret", RefreshToken: "refresh-secret"}
+	card := business.CardSensitiveDetailsResp{Pan: "4111111111111111", Cvv: "123", Expiry: "09/2027", CardholderName: "Jane Doe"}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		raw     interface{}
+		secrets []string
+	}{
+		{"token", token, (*business.RawToken)(&token), []string{"access-secret", "refresh-secret"}},
+		{"card details", card, (*business.RawCardSensitiveDetails)(&card), []string{"4111111111111111", "123", "09/2027"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			outputs := []string{fmt.Sprint(tt.value), fmt.Sprintf("%+v", tt.value), fmt.Sprintf("%#v", tt.value), string(b)}
+			for _, out := range outputs {
+				for _, secret := range tt.secrets {
+					if strings.Contains(out, secret) {
+						t.Errorf("%s leaked in %s", secret, out)
+					}
+				}
+			}
+
+			raw, err := json.Marshal(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, secret := range tt.secrets {
+				if !strings.Contains(string(raw), secret) {
+					t.Errorf("got %s, want %s kept in the raw value", raw, secret)
+				}
+			}
+		})
+	}
+
+	if s := card.String(); !strings.Contains(s, "Jane Doe") {
+		t.Errorf("got %s, want the cardholder name", s)
+	}
+}
@@ -0,0 +1,35 @@
+package request
+
+import "context"
+
+// ActingUser identifies the human operator on whose behalf a shared service account makes a call.
+type ActingUser struct {
+	// a stable ID of the operator, e.g. the user ID in your identity provider
+	Id string
+	// an optional display name
+	Name string
+}
+
+func (u *ActingUser) String() string {
+	if u.Name == "" {
+		return u.Id
+	}
+	return u.Name + " (" + u.Id + ")"
+}
+
+type actingUserKey struct{}
+
+// WithActingUser attaches the acting user to the context. It is recorded in request logs, spans,
+// audit entries and handed to approvers for every call made with the context.
+func WithActingUser(ctx context.Context, user *ActingUser) context.Context {
+	return context.WithValue(ctx, actingUserKey{}, user)
+}
+
+// ActingUserFromContext returns the acting user attached to the context, or nil.
+func ActingUserFromContext(ctx context.Context) *ActingUser {
+	if ctx == nil {
+		return nil
+	}
+	u, _ := ctx.Value(actingUserKey{}).(*ActingUser)
+	return u
+}
@@ -0,0 +1,183 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, without sending the request, while a CircuitBreaker is open. It is never retried.
+var ErrCircuitOpen = errors.New("request: circuit breaker open, Revolut is failing")
+
+type CircuitState int
+
+const (
+	// requests are sent, failures are counted
+	CircuitState_CLOSED CircuitState = iota
+	// requests fail fast with ErrCircuitOpen
+	CircuitState_OPEN
+	// a few probe requests are sent to find out whether Revolut recovered, the others fail fast
+	CircuitState_HALF_OPEN
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitState_CLOSED:
+		return "closed"
+	case CircuitState_OPEN:
+		return "open"
+	case CircuitState_HALF_OPEN:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker fails requests fast during sustained outages instead of letting every caller wait for its
+// timeout. Network errors and 5xx responses are failures; other responses, 429s included, are successes.
+// After FailureThreshold consecutive failures the circuit opens for OpenTimeout, then lets HalfOpenProbes requests
+// through: it closes once they all succeed and opens again at the first failure.
+//
+// A breaker is shared by the clients it is given to, and is safe for concurrent use. The zero value is ready to use.
+type CircuitBreaker struct {
+	// the consecutive failures opening the circuit, 5 when zero
+	FailureThreshold int
+	// how long the circuit stays open before probing, 30 seconds when zero
+	OpenTimeout time.Duration
+	// the successful probes closing the circuit, 1 when zero
+	HalfOpenProbes int
+	// an optional hook called on every change of state, with the breaker locked: it must not block nor call it
+	OnStateChange func(from, to CircuitState)
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// the probes in flight and those that succeeded in the current half-open state
+	probing   int
+	succeeded int
+	// counts the changes of state, so outcomes of requests sent before one are ignored
+	generation uint64
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitState_OPEN && time.Since(b.openedAt) >= b.openTimeout() {
+		return CircuitState_HALF_OPEN
+	}
+	return b.state
+}
+
+// Middleware returns the middleware guarding requests with the breaker.
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			gen, probe, err := b.allow()
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.Do(req)
+			b.done(req.Context(), gen, probe, resp, err)
+			return resp, err
+		})
+	}
+}
+
+// allow reports whether a request may be sent, the generation of the state it is sent in and whether it is a probe
+func (b *CircuitBreaker) allow() (uint64, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitState_OPEN:
+		if time.Since(b.openedAt) < b.openTimeout() {
+			return 0, false, ErrCircuitOpen
+		}
+		b.transition(CircuitState_HALF_OPEN)
+		fallthrough
+	case CircuitState_HALF_OPEN:
+		if b.probing+b.succeeded >= b.halfOpenProbes() {
+			return 0, false, ErrCircuitOpen
+		}
+		b.probing++
+		return b.generation, true, nil
+	}
+	return b.generation, false, nil
+}
+
+// done records the outcome of a request, unless the state changed since it was sent
+func (b *CircuitBreaker) done(ctx context.Context, gen uint64, probe bool, resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if gen != b.generation {
+		return
+	}
+	if probe {
+		b.probing--
+	}
+	// a caller giving up says nothing about Revolut
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+
+	switch b.state {
+	case CircuitState_CLOSED:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		if b.failures++; b.failures >= b.failureThreshold() {
+			b.open()
+		}
+	case CircuitState_HALF_OPEN:
+		if failed {
+			b.open()
+			return
+		}
+		if b.succeeded++; b.succeeded >= b.halfOpenProbes() {
+			b.failures = 0
+			b.transition(CircuitState_CLOSED)
+		}
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = time.Now()
+	b.transition(CircuitState_OPEN)
+}
+
+func (b *CircuitBreaker) transition(to CircuitState) {
+	from := b.state
+	b.state, b.probing, b.succeeded = to, 0, 0
+	b.generation++
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
+
+func (b *CircuitBreaker) failureThreshold() int {
+	if b.FailureThreshold <= 0 {
+		return 5
+	}
+	return b.FailureThreshold
+}
+
+func (b *CircuitBreaker) openTimeout() time.Duration {
+	if b.OpenTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return b.OpenTimeout
+}
+
+func (b *CircuitBreaker) halfOpenProbes() int {
+	if b.HalfOpenProbes <= 0 {
+		return 1
+	}
+	return b.HalfOpenProbes
+}
@@ -0,0 +1,16 @@
+//go:build !js
+
+package request
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+func dialContext(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+}
@@ -0,0 +1,15 @@
+//go:build js
+
+package request
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// dialContext returns no dialer on js/wasm: net/http only sends requests through the browser's
+// fetch API when the transport has no custom dialer, there are no raw sockets to dial.
+func dialContext(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return nil
+}
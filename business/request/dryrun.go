@@ -0,0 +1,47 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrDryRun matches, with errors.Is, the error returned for every request skipped by Options.DryRun.
+var ErrDryRun = errors.New("request: dry run")
+
+// DryRunError is returned instead of sending a mutating request when Options.DryRun is set.
+// It carries the request that would have been sent.
+type DryRunError struct {
+	// a short name of the skipped endpoint, e.g. "payment.create"
+	Operation string
+	// the HTTP method
+	Method string
+	// the url, rewritten to the sandbox host when applicable
+	Url string
+	// the body that would have been sent, with tokens, authorisation codes and client assertions masked like in
+	// the logs, so the error can be printed whatever the operation
+	Body string
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("request: dry run of %s %s: %s", e.Method, e.Url, e.Body)
+}
+
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// dryRun reports whether the request must be skipped: every request but reads and token grants, which
+// are needed to authenticate the reads, is skipped in dry run mode.
+func (conf *Config) dryRun() bool {
+	if conf.Options == nil || !conf.Options.DryRun {
+		return false
+	}
+	return conf.Method != http.MethodGet && conf.Method != http.MethodHead && !tokenGrants[conf.Operation]
+}
+
+// tokenGrants are the operations obtaining access tokens
+var tokenGrants = map[string]bool{
+	"oauth.exchange_authorisation_code": true,
+	"oauth.refresh_access_token":        true,
+}
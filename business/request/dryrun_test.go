@@ -0,0 +1,68 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDryRunRedactsBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		body      url.Values
+		secrets   []string
+	}{
+		{"revoke", "oauth.revoke_token", url.Values{
+			"token":                 {"refresh-secret"},
+			"token_type_hint":       {"refresh_token"},
+			"client_id":             {"client"},
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {"assertion-secret"},
+		}, []string{"refresh-secret", "assertion-secret"}},
+		{"other form", "oauth.other", url.Values{
+			"grant_type":       {"authorization_code"},
+			"code":             {"code-secret"},
+			"refresh_token":    {"refresh-secret"},
+			"client_assertion": {"assertion-secret"},
+		}, []string{"code-secret", "refresh-secret", "assertion-secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := New(Config{
+				Operation:   tt.operation,
+				Method:      http.MethodPost,
+				Url:         "https://b2b.revolut.com/api/1.0/auth/revoke",
+				Options:     &Options{DryRun: true},
+				Body:        tt.body,
+				ContentType: ContentType_APPLICATION_FORM,
+			})
+
+			var dryRun *DryRunError
+			if !errors.As(err, &dryRun) {
+				t.Fatalf("got %v, want a dry run error", err)
+			}
+			for _, secret := range tt.secrets {
+				if strings.Contains(dryRun.Body, secret) || strings.Contains(err.Error(), secret) {
+					t.Errorf("%s leaked in %q", secret, err)
+				}
+			}
+			if !strings.Contains(dryRun.Body, "client_assertion=***") {
+				t.Errorf("got body %q, want the client assertion masked", dryRun.Body)
+			}
+		})
+	}
+}
+
+func TestDryRunSendsTokenGrants(t *testing.T) {
+	conf := &Config{Operation: "oauth.refresh_access_token", Method: http.MethodPost, Options: &Options{DryRun: true}}
+	if conf.dryRun() {
+		t.Error("token grants must be sent in dry run")
+	}
+	conf.Operation = "oauth.revoke_token"
+	if !conf.dryRun() {
+		t.Error("revocations must be skipped in dry run")
+	}
+}
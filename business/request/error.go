@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ClientRequestIdHeader carries the ID generated by the library for every call
+	ClientRequestIdHeader = "X-Client-Request-Id"
+	// RequestIdHeader carries the ID Revolut assigns to a call
+	RequestIdHeader = "X-Request-Id"
+	// TraceIdHeader carries the ID of the trace of a call across Revolut's services
+	TraceIdHeader = "X-Trace-Id"
+)
+
+// sensitiveHeaders are left out of the headers kept on errors and log entries
+var sensitiveHeaders = []string{"Set-Cookie", "Authorization"}
+
+// APIError is returned for every response with a 4xx or 5xx status code.
+type APIError struct {
+	// the HTTP status code
+	StatusCode int
+	// the raw response body
+	Body string
+	// the ID sent in the X-Client-Request-Id header
+	ClientRequestId string
+	// the ID returned by Revolut, empty if the response carried none
+	RequestId string
+	// the trace ID returned by Revolut, empty if the response carried none
+	TraceId string
+	// the response headers, without cookies
+	Header http.Header
+}
+
+func (e *APIError) Error() string {
+	if e.TraceId != "" {
+		return fmt.Sprintf("%s (status: %d, client request id: %s, request id: %s, trace id: %s)", e.Body, e.StatusCode, e.ClientRequestId, e.RequestId, e.TraceId)
+	}
+	return fmt.Sprintf("%s (status: %d, client request id: %s, request id: %s)", e.Body, e.StatusCode, e.ClientRequestId, e.RequestId)
+}
+
+// responseRequestId returns the request ID Revolut attached to the response.
+func responseRequestId(h http.Header) string {
+	if id := h.Get(RequestIdHeader); id != "" {
+		return id
+	}
+	return h.Get("Request-Id")
+}
+
+// responseTraceId returns the trace ID Revolut attached to the response, read from the W3C traceparent
+// header when there is no X-Trace-Id.
+func responseTraceId(h http.Header) string {
+	if id := h.Get(TraceIdHeader); id != "" {
+		return id
+	}
+	// traceparent is version-traceid-parentid-flags
+	if parts := strings.Split(h.Get("Traceparent"), "-"); len(parts) == 4 {
+		return parts[1]
+	}
+	return ""
+}
+
+// responseHeader returns a copy of the response headers without the sensitive ones.
+func responseHeader(h http.Header) http.Header {
+	c := h.Clone()
+	for _, name := range sensitiveHeaders {
+		c.Del(name)
+	}
+	return c
+}
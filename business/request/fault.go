@@ -0,0 +1,150 @@
+package request
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Faults configures FaultInjection. Every probability is between 0 and 1; at most one failure is injected per
+// attempt, tried in the order connection reset, 429 then 5xx, after the latency.
+type Faults struct {
+	// the probability of delaying an attempt, by up to MaxLatency
+	Latency float64
+	// the longest delay injected, picked uniformly, 1 second when zero
+	MaxLatency time.Duration
+	// the probability of answering an attempt with a 429
+	RateLimited float64
+	// the Retry-After of the injected 429s, 1 second when zero
+	RetryAfter time.Duration
+	// the probability of answering an attempt with a 5xx
+	ServerError float64
+	// the status of the injected 5xx, 503 when zero
+	ServerErrorStatus int
+	// the probability of failing an attempt with a connection reset, before it reaches the server
+	ConnectionReset float64
+	// the operations faults are injected into, e.g. "account.list", every operation when empty
+	Operations []string
+	// the seed of the random source, for reproducible runs; the current time when zero
+	Seed int64
+}
+
+// FaultInjection returns a middleware failing attempts at random as the faults say, to test the retry and
+// alerting behaviour of an application against the library. Injected 429s and 5xx are responses, read like
+// those of Revolut; resets are network errors, as the HTTP client returns them. It is safe for concurrent use.
+//
+//	bC, err := business.NewClient(..., business.WithMiddleware(request.FaultInjection(request.Faults{
+//		RateLimited: 0.1,
+//		ServerError: 0.05,
+//	})))
+func FaultInjection(f Faults) Middleware {
+	seed := f.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	inj := &injector{Faults: f, rnd: rand.New(rand.NewSource(seed))}
+	if inj.MaxLatency == 0 {
+		inj.MaxLatency = time.Second
+	}
+	if inj.RetryAfter == 0 {
+		inj.RetryAfter = time.Second
+	}
+	if inj.ServerErrorStatus == 0 {
+		inj.ServerErrorStatus = http.StatusServiceUnavailable
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if !inj.applies(OperationFromContext(req.Context())) {
+				return next.Do(req)
+			}
+
+			if d := inj.latency(); d > 0 {
+				if err := sleep(req.Context(), d); err != nil {
+					return nil, &url.Error{Op: urlOp(req.Method), URL: req.URL.String(), Err: err}
+				}
+			}
+			switch {
+			case inj.roll(inj.ConnectionReset):
+				return nil, &url.Error{Op: urlOp(req.Method), URL: req.URL.String(),
+					Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+			case inj.roll(inj.RateLimited):
+				resp := injectedResponse(req, http.StatusTooManyRequests)
+				resp.Header.Set("Retry-After", strconv.Itoa(int((inj.RetryAfter+time.Second-1)/time.Second)))
+				return resp, nil
+			case inj.roll(inj.ServerError):
+				return injectedResponse(req, inj.ServerErrorStatus), nil
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+type injector struct {
+	Faults
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func (inj *injector) applies(operation string) bool {
+	if len(inj.Operations) == 0 {
+		return true
+	}
+	for _, op := range inj.Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// roll reports whether a fault of the probability happens
+func (inj *injector) roll(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+	return inj.rnd.Float64() < p
+}
+
+func (inj *injector) latency() time.Duration {
+	if !inj.roll(inj.Latency) {
+		return 0
+	}
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+	return time.Duration(inj.rnd.Int63n(int64(inj.MaxLatency) + 1))
+}
+
+// injectedResponse returns a response with the status and a body shaped like the errors of Revolut
+func injectedResponse(req *http.Request, status int) *http.Response {
+	body := `{"message":"` + http.StatusText(status) + ` (injected)","code":` + strconv.Itoa(status) + `}`
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// urlOp returns the operation of the errors of the HTTP client, e.g. "Get"
+func urlOp(method string) string {
+	if method == "" {
+		return "Get"
+	}
+	return method[:1] + strings.ToLower(method[1:])
+}
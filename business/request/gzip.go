@@ -0,0 +1,17 @@
+package request
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// responseBody returns the decompressed body of the response. Accept-Encoding is set explicitly on every request,
+// which turns off the transparent decompression of net/http, so that any transport gets compressed responses.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	return gzip.NewReader(resp.Body)
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultUserAgent is sent by every request without a user agent of its own.
+const DefaultUserAgent = "go-revolut"
+
+type headerKey struct{}
+
+// WithHeader attaches extra headers to the context; they are sent with every call made with the context,
+// on top of the headers of the client.
+func WithHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// HeaderFromContext returns the extra headers attached to the context, or nil.
+func HeaderFromContext(ctx context.Context) http.Header {
+	if ctx == nil {
+		return nil
+	}
+	h, _ := ctx.Value(headerKey{}).(http.Header)
+	return h
+}
+
+// setHeader sets the extra headers of the client, then those of the context and the request, each one replacing
+// the values of the previous ones. The headers set by the library itself are set afterwards and always win.
+func (conf *Config) setHeader(ctx context.Context, req *http.Request) {
+	userAgent := DefaultUserAgent
+	if conf.Options != nil {
+		if conf.Options.UserAgent != "" {
+			userAgent = conf.Options.UserAgent
+		}
+		copyHeader(req.Header, conf.Options.Header)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	copyHeader(req.Header, HeaderFromContext(ctx))
+	copyHeader(req.Header, conf.Header)
+}
+
+func copyHeader(dst, src http.Header) {
+	for name, values := range src {
+		dst[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+}
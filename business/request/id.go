@@ -0,0 +1,87 @@
+package request
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// IDGenerator creates the identifiers the library attaches to calls, the client request IDs and the
+// request_id of payments, transfers and exchanges created without one.
+type IDGenerator interface {
+	NewId() (string, error)
+}
+
+// IDGeneratorFunc adapts a function to the IDGenerator interface.
+type IDGeneratorFunc func() (string, error)
+
+func (f IDGeneratorFunc) NewId() (string, error) {
+	return f()
+}
+
+// UUIDGenerator generates random version 4 UUIDs, the default.
+type UUIDGenerator struct{}
+
+func (UUIDGenerator) NewId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// ULIDGenerator generates ULIDs, 26 characters sorting by creation time to the millisecond.
+type ULIDGenerator struct{}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func (ULIDGenerator) NewId() (string, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", err
+	}
+
+	// 128 bits in 26 groups of 5, the first group holding the 3 leftmost bits
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	r := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		r[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(r), nil
+}
+
+// PrefixGenerator prepends a fixed prefix, e.g. an environment or tenant, to the IDs of another generator.
+// Keep the prefix short: Revolut accepts request IDs of up to 40 characters.
+type PrefixGenerator struct {
+	Prefix string
+	// the generator of the rest of the ID, UUIDGenerator when nil
+	Next IDGenerator
+}
+
+func (g *PrefixGenerator) NewId() (string, error) {
+	next := g.Next
+	if next == nil {
+		next = UUIDGenerator{}
+	}
+
+	id, err := next.NewId()
+	if err != nil {
+		return "", err
+	}
+	return g.Prefix + id, nil
+}
+
+// NewId returns an identifier from the generator of the options, a UUID when none is set.
+func (o *Options) NewId() (string, error) {
+	if o == nil || o.IDGenerator == nil {
+		return UUIDGenerator{}.NewId()
+	}
+	return o.IDGenerator.NewId()
+}
@@ -0,0 +1,96 @@
+package request
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter blocks until a request is allowed to be sent.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter refilling rps tokens per second up to burst tokens.
+// It is safe for concurrent use, so one bucket can be shared by every service of a client.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// no token is handed out before this instant, set when Revolut reports the budget exhausted
+	pausedUntil time.Time
+}
+
+// NewTokenBucket returns a full bucket allowing rps requests per second with bursts of up to burst requests.
+func NewTokenBucket(rps float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rps:    rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait takes a token from the bucket, sleeping until one is available or the context is done.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := tb.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns the time until the next token.
+func (tb *TokenBucket) reserve() time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(tb.pausedUntil) {
+		return tb.pausedUntil.Sub(now)
+	}
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rps
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+	if tb.rps <= 0 {
+		return time.Second
+	}
+
+	return time.Duration((1 - tb.tokens) / tb.rps * float64(time.Second))
+}
+
+// ObserveRateLimit adapts the bucket to the budget reported by Revolut: it never holds more tokens than requests
+// remain, and pauses until the reset once none does.
+func (tb *TokenBucket) ObserveRateLimit(rl *RateLimit) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if rl.Remaining >= 0 && float64(rl.Remaining) < tb.tokens {
+		tb.tokens = float64(rl.Remaining)
+	}
+	if rl.Exhausted() && rl.Reset.After(tb.pausedUntil) {
+		tb.pausedUntil = rl.Reset
+	}
+}
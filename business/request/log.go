@@ -0,0 +1,88 @@
+package request
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// LogEntry describes a single round trip to the API.
+type LogEntry struct {
+	// the HTTP method
+	Method string
+	// the requested url, rewritten to the sandbox host when applicable
+	Url string
+	// the ID sent in the X-Client-Request-Id header
+	ClientRequestId string
+	// the request ID returned by Revolut, if any
+	RequestId string
+	// the trace ID returned by Revolut, if any
+	TraceId string
+	// the response headers without cookies, nil when no response was received
+	ResponseHeader http.Header
+	// the operator the call was made on behalf of, if any
+	ActingUser *ActingUser
+	// the response status code, zero when no response was received
+	StatusCode int
+	// the time spent waiting for the response
+	Latency time.Duration
+	// the request body with tokens and client assertions masked
+	RequestBody string
+	// the response body with tokens masked
+	ResponseBody string
+	// the transport error, if any
+	Err error
+}
+
+// Logger receives an entry for every request made by the client.
+type Logger interface {
+	Log(entry *LogEntry)
+}
+
+// LoggerFunc adapts an ordinary function to the Logger interface.
+type LoggerFunc func(entry *LogEntry)
+
+func (f LoggerFunc) Log(entry *LogEntry) {
+	f(entry)
+}
+
+// NewStdLogger returns a Logger writing one line per request to the given standard library logger, with the
+// trace ID and the acting user when present.
+func NewStdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(e *LogEntry) {
+		ids := e.ClientRequestId
+		if e.Err == nil {
+			ids += "/" + e.RequestId
+		}
+		if e.TraceId != "" {
+			ids += " trace " + e.TraceId
+		}
+		line := fmt.Sprintf("revolut: %s %s [%s]", e.Method, e.Url, ids)
+		if e.ActingUser != nil {
+			line += fmt.Sprintf(" as %s", e.ActingUser)
+		}
+
+		if e.Err != nil {
+			l.Printf("%s failed after %s: %v", line, e.Latency, e.Err)
+			return
+		}
+		l.Printf("%s %d (%s) request=%s response=%s", line, e.StatusCode, e.Latency, e.RequestBody, e.ResponseBody)
+	})
+}
+
+const redacted = "***"
+
+var (
+	jsonSecret = regexp.MustCompile(`("(?:access_token|refresh_token|client_assertion|pan|cvv)"\s*:\s*)"[^"]*"`)
+	formSecret = regexp.MustCompile(`((?:^|&)(?:client_assertion|refresh_token|code|token)=)[^&]*`)
+)
+
+// Redact masks access tokens, refresh tokens, authorisation codes, client assertions and card numbers and security
+// codes in a request or response body.
+func Redact(b []byte) string {
+	b = jsonSecret.ReplaceAll(b, []byte(`$1"`+redacted+`"`))
+	b = formSecret.ReplaceAll(b, []byte(`${1}`+redacted))
+	return string(b)
+}
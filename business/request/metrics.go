@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+// MetricsCollector receives per endpoint measurements of every request, e.g. to feed Prometheus counters and histograms.
+// The endpoint is the operation name of the request, such as "payment.create".
+type MetricsCollector interface {
+	// IncRequest counts a request sent to the endpoint
+	IncRequest(endpoint string)
+	// IncError counts a failed request, statusCode is zero when no response was received
+	IncError(endpoint string, statusCode int)
+	// ObserveLatency records the time spent waiting for the response
+	ObserveLatency(endpoint string, latency time.Duration)
+}
+
+func (conf *Config) record(entry *LogEntry) {
+	if conf.Options == nil || conf.Options.Metrics == nil {
+		return
+	}
+
+	endpoint := conf.Operation
+	if endpoint == "" {
+		endpoint = conf.Method
+	}
+
+	m := conf.Options.Metrics
+	m.IncRequest(endpoint)
+	m.ObserveLatency(endpoint, entry.Latency)
+	if entry.Err != nil || entry.StatusCode >= 400 {
+		m.IncError(endpoint, entry.StatusCode)
+	}
+}
@@ -0,0 +1,58 @@
+package request
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending a request, e.g. to add headers, log, cache or fail requests on purpose.
+// A middleware may change the request, answer it without calling next, or change the response; the response it
+// returns is read by the library as if Revolut had sent it.
+//
+// Middlewares run for every attempt of a request, once the library set its headers, including the access token
+// and the client request ID, and within the rate limit, span and log entry of the attempt.
+type Middleware func(next Doer) Doer
+
+// Chain composes the middlewares into one, the first one being the outermost: it sees the request first and the
+// response last.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Doer) Doer {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+type operationKey struct{}
+
+// OperationFromContext returns the operation of the request whose context it is, e.g. "account.list", so a
+// middleware can tell endpoints apart without parsing urls. It is empty outside of the library's requests.
+func OperationFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
+
+// doer returns the HTTP client of the request wrapped in the middlewares of the options
+func (conf *Config) doer() Doer {
+	var d Doer = conf.httpClient()
+	if conf.Options != nil && len(conf.Options.Middleware) > 0 {
+		d = Chain(conf.Options.Middleware...)(d)
+	}
+	return d
+}
@@ -0,0 +1,118 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit is the request budget Revolut reported in the headers of a response.
+type RateLimit struct {
+	// the number of requests allowed in the current window, -1 when not reported
+	Limit int
+	// the number of requests left in the current window, -1 when not reported
+	Remaining int
+	// the instant the window resets, zero when not reported
+	Reset time.Time
+	// the instant the response was received
+	ObservedAt time.Time
+}
+
+// Exhausted reports whether no request is left until Reset.
+func (rl *RateLimit) Exhausted() bool {
+	return rl.Remaining == 0 && rl.ObservedAt.Before(rl.Reset)
+}
+
+// ParseRateLimit reads the rate limit headers of a response, and Retry-After on a 429. It returns nil when
+// the response carries none. X-RateLimit-Reset may be a number of seconds or a Unix time.
+func ParseRateLimit(statusCode int, h http.Header, now time.Time) *RateLimit {
+	rl := &RateLimit{
+		Limit:      headerInt(h, RateLimitLimitHeader),
+		Remaining:  headerInt(h, RateLimitRemainingHeader),
+		ObservedAt: now,
+	}
+
+	if reset := headerInt(h, RateLimitResetHeader); reset >= 0 {
+		// values up to a year are delays, above they are instants
+		if reset < 365*24*3600 {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		} else {
+			rl.Reset = time.Unix(int64(reset), 0)
+		}
+	}
+	if statusCode == http.StatusTooManyRequests {
+		if after := h.Get("Retry-After"); after != "" {
+			if s, err := strconv.Atoi(after); err == nil {
+				rl.Reset = now.Add(time.Duration(s) * time.Second)
+			} else if t, err := http.ParseTime(after); err == nil {
+				rl.Reset = t
+			}
+			rl.Remaining = 0
+		}
+	}
+
+	if rl.Limit < 0 && rl.Remaining < 0 && rl.Reset.IsZero() {
+		return nil
+	}
+	return rl
+}
+
+func headerInt(h http.Header, name string) int {
+	n, err := strconv.Atoi(h.Get(name))
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// RateLimitObserver can optionally be implemented by a RateLimiter to adapt to the budget reported by Revolut.
+type RateLimitObserver interface {
+	ObserveRateLimit(rl *RateLimit)
+}
+
+// RateLimitTracker keeps the latest rate limit reported by Revolut. It is safe for concurrent use.
+type RateLimitTracker struct {
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+// Last returns a copy of the latest rate limit observed, nil when none was.
+func (t *RateLimitTracker) Last() *RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.last == nil {
+		return nil
+	}
+	rl := *t.last
+	return &rl
+}
+
+func (t *RateLimitTracker) ObserveRateLimit(rl *RateLimit) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.last == nil || !rl.ObservedAt.Before(t.last.ObservedAt) {
+		t.last = rl
+	}
+}
+
+// observeRateLimit hands the rate limit of a response to the tracker and the limiter.
+func (conf *Config) observeRateLimit(rl *RateLimit) {
+	if rl == nil || conf.Options == nil {
+		return
+	}
+	if conf.Options.RateLimits != nil {
+		conf.Options.RateLimits.ObserveRateLimit(rl)
+	}
+	if o, ok := conf.Options.Limiter.(RateLimitObserver); ok {
+		o.ObserveRateLimit(rl)
+	}
+}
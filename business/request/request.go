@@ -0,0 +1,272 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Config struct {
+	// an optional context, defaults to context.Background
+	Context context.Context
+	// a short name of the called endpoint, e.g. "account.list", used to name spans
+	Operation   string
+	Method      string
+	Url         string
+	AccessToken string
+	Sandbox     bool
+	Body        interface{}
+	ContentType ContentType
+	Options     *Options
+	// optional extra headers of this request, e.g. a partner identification header
+	Header http.Header
+	// the request_id of a mutating request, which Revolut deduplicates on. Without one, a mutating request
+	// is never retried
+	IdempotencyKey string
+	// whether the library generated the idempotency key, see RetryPolicy.GeneratedKeys
+	GeneratedKey bool
+	// an optional decoder of successful responses, set it to read large bodies as a stream
+	// instead of buffering them; New then returns no body
+	Stream func(body io.Reader) error
+}
+
+// Options holds the hooks shared by every request of a client.
+type Options struct {
+	// an optional logger notified after every request
+	Logger Logger
+	// an optional OpenTelemetry tracer provider, every request is recorded as a client span
+	TracerProvider trace.TracerProvider
+	// an optional collector of per endpoint request metrics
+	Metrics MetricsCollector
+	// an optional limiter every request waits on before being sent
+	Limiter RateLimiter
+	// an optional HTTP client, requests share a pooled default client otherwise
+	HTTPClient *http.Client
+	// an optional generator of the identifiers created by the library, UUIDs otherwise
+	IDGenerator IDGenerator
+	// an optional User-Agent, DefaultUserAgent otherwise
+	UserAgent string
+	// optional extra headers sent with every request
+	Header http.Header
+	// the overall timeout of a request whose context has no deadline, retries included, DefaultTimeout when zero,
+	// none when negative
+	Timeout time.Duration
+	// an optional tracker of the rate limits reported by Revolut
+	RateLimits *RateLimitTracker
+	// an optional policy retrying transient failures
+	Retry *RetryPolicy
+	// skip every POST, PATCH, PUT and DELETE, returning a *DryRunError with the request instead
+	DryRun bool
+	// optional middlewares wrapping the HTTP client, the first one outermost, see Middleware
+	Middleware []Middleware
+}
+
+type ContentType string
+
+const (
+	ContentType_APPLICATION_FORM ContentType = "application/x-www-form-urlencoded"
+	ContentType_APPLICATION_JSON ContentType = "application/json"
+)
+
+func New(conf Config) ([]byte, int, error) {
+
+	var b []byte
+	var err error
+
+	switch conf.ContentType {
+	case ContentType_APPLICATION_FORM:
+		b = []byte(conf.Body.(url.Values).Encode())
+
+	case ContentType_APPLICATION_JSON:
+		b, err = json.Marshal(conf.Body)
+		if err != nil {
+			return []byte{}, 0, err
+		}
+	}
+
+	if conf.Sandbox {
+		conf.Url, err = SandboxUrl(conf.Url)
+		if err != nil {
+			return []byte{}, 0, err
+		}
+	}
+
+	// the timeout bounds the request as a whole, its retries and their backoff included
+	if _, ok := conf.context().Deadline(); !ok {
+		if timeout := conf.timeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			conf.Context, cancel = context.WithTimeout(conf.context(), timeout)
+			defer cancel()
+		}
+	}
+
+	// every attempt sends the same body, so the same request_id
+	for retry := 1; ; retry++ {
+		resp, statusCode, err := conf.send(b)
+		if retry > conf.retries() || !conf.retryable(err) {
+			return resp, statusCode, err
+		}
+		if err := sleep(conf.context(), conf.backoff(retry, err)); err != nil {
+			return resp, statusCode, err
+		}
+	}
+}
+
+func (conf *Config) context() context.Context {
+	if conf.Context == nil {
+		return context.Background()
+	}
+	return conf.Context
+}
+
+func (conf *Config) retries() int {
+	if conf.Options == nil || conf.Options.Retry == nil {
+		return 0
+	}
+	return conf.Options.Retry.Retries
+}
+
+// send makes a single attempt of the request with the encoded body.
+func (conf *Config) send(b []byte) ([]byte, int, error) {
+	clientRequestId, err := conf.Options.NewId()
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	ctx := conf.context()
+	if conf.Options != nil && conf.Options.Limiter != nil {
+		if err := conf.Options.Limiter.Wait(ctx); err != nil {
+			return []byte{}, 0, err
+		}
+	}
+	ctx, span := conf.startSpan(ctx)
+
+	if conf.dryRun() {
+		err := &DryRunError{Operation: conf.Operation, Method: conf.Method, Url: conf.Url, Body: Redact(b)}
+		conf.finish(span, &LogEntry{
+			Method:          conf.Method,
+			Url:             conf.Url,
+			ClientRequestId: clientRequestId,
+			ActingUser:      ActingUserFromContext(ctx),
+			RequestBody:     Redact(b),
+			Err:             err,
+		})
+		return []byte{}, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, operationKey{}, conf.Operation), conf.Method, conf.Url, bytes.NewReader(b))
+	if err != nil {
+		span.End()
+		return []byte{}, 0, err
+	}
+
+	conf.setHeader(ctx, req)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", conf.AccessToken))
+	req.Header.Set(ClientRequestIdHeader, clientRequestId)
+	req.Header.Set("Accept-Encoding", "gzip")
+	if conf.ContentType != "" {
+		req.Header.Set("Content-Type", string(conf.ContentType))
+	}
+
+	entry := &LogEntry{
+		Method:          conf.Method,
+		Url:             conf.Url,
+		ClientRequestId: clientRequestId,
+		ActingUser:      ActingUserFromContext(ctx),
+		RequestBody:     Redact(b),
+	}
+
+	start := time.Now()
+	resp, err := conf.doer().Do(req)
+	entry.Latency = time.Since(start)
+	if err != nil {
+		entry.Err = err
+		conf.finish(span, entry)
+		return []byte{}, 0, err
+	}
+	// drain whatever is left of the body so the connection can go back to the pool
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	entry.StatusCode = resp.StatusCode
+	entry.RequestId = responseRequestId(resp.Header)
+	entry.TraceId = responseTraceId(resp.Header)
+	entry.ResponseHeader = responseHeader(resp.Header)
+	rateLimit := ParseRateLimit(resp.StatusCode, resp.Header, time.Now())
+	conf.observeRateLimit(rateLimit)
+
+	body, err := responseBody(resp)
+	if err != nil {
+		entry.Err = err
+		conf.finish(span, entry)
+		return []byte{}, 0, err
+	}
+
+	if conf.Stream != nil && resp.StatusCode < http.StatusMultipleChoices {
+		entry.ResponseBody = "(streamed)"
+		recordResponse(ctx, entry, rateLimit, nil)
+		entry.Err = conf.Stream(body)
+		conf.finish(span, entry)
+		return nil, resp.StatusCode, entry.Err
+	}
+
+	b, err = ioutil.ReadAll(body)
+	entry.ResponseBody = Redact(b)
+	recordResponse(ctx, entry, rateLimit, b)
+	entry.Err = err
+	conf.finish(span, entry)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	if echoed := resp.Header.Get(ClientRequestIdHeader); echoed != "" && echoed != clientRequestId {
+		return b, resp.StatusCode, fmt.Errorf("request: response echoed client request id %s, sent %s", echoed, clientRequestId)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return b, resp.StatusCode, &APIError{
+			StatusCode:      resp.StatusCode,
+			Body:            string(b),
+			ClientRequestId: clientRequestId,
+			RequestId:       entry.RequestId,
+			TraceId:         entry.TraceId,
+			Header:          entry.ResponseHeader,
+		}
+	}
+
+	return b, resp.StatusCode, nil
+}
+
+// DefaultTimeout bounds every request whose context has no deadline, unless Options.Timeout says otherwise.
+const DefaultTimeout = time.Minute
+
+func (conf *Config) timeout() time.Duration {
+	if conf.Options == nil || conf.Options.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return conf.Options.Timeout
+}
+
+// finish reports a completed request to the logger, tracer and metrics collector.
+func (conf *Config) finish(span trace.Span, entry *LogEntry) {
+	conf.log(entry)
+	conf.record(entry)
+	endSpan(span, entry)
+}
+
+func (conf *Config) log(entry *LogEntry) {
+	if conf.Options == nil || conf.Options.Logger == nil {
+		return
+	}
+	conf.Options.Logger.Log(entry)
+}
@@ -0,0 +1,57 @@
+package request
+
+import (
+	"context"
+	"net/http"
+)
+
+// Response holds the HTTP metadata of a call, for callers that need more than its decoded result,
+// e.g. to read pagination or rate limit headers.
+type Response struct {
+	// the HTTP status code
+	StatusCode int
+	// the response headers, without cookies
+	Header http.Header
+	// the raw response body, nil when it was streamed
+	Body []byte
+	// the ID sent in the X-Client-Request-Id header
+	ClientRequestId string
+	// the request ID returned by Revolut, if any
+	RequestId string
+	// the trace ID returned by Revolut, if any
+	TraceId string
+	// the rate limit reported in the headers, nil when there was none
+	RateLimit *RateLimit
+}
+
+type responseKey struct{}
+
+// WithResponse makes every call made with the returned context record its response in resp:
+//
+//	var resp request.Response
+//	accounts, err := account.List(request.WithResponse(ctx, &resp))
+//	fmt.Println(resp.StatusCode, resp.Header)
+//
+// Calls sharing the context overwrite each other's response, the last one wins. The context must not be shared
+// by concurrent calls.
+func WithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+// recordResponse fills the response attached to the context, if any.
+func recordResponse(ctx context.Context, entry *LogEntry, rateLimit *RateLimit, body []byte) {
+	resp, _ := ctx.Value(responseKey{}).(*Response)
+	if resp == nil {
+		return
+	}
+
+	*resp = Response{
+		StatusCode:      entry.StatusCode,
+		Header:          entry.ResponseHeader,
+		Body:            body,
+		ClientRequestId: entry.ClientRequestId,
+		RequestId:       entry.RequestId,
+		TraceId:         entry.TraceId,
+		RateLimit:       rateLimit,
+	}
+}
@@ -0,0 +1,73 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy retries requests failing with a network error, a 429 or a 5xx response. Reads are always
+// retried; POST, PATCH, PUT and DELETE only when they carry an idempotency key, the request_id Revolut
+// deduplicates them on, so a retried payment or exchange cannot be executed twice.
+type RetryPolicy struct {
+	// the number of retries after the first attempt
+	Retries int
+	// the delay before the first retry, doubled on every following one, 500ms when zero.
+	// A longer Retry-After of a 429 is honoured
+	Backoff time.Duration
+	// also retry mutating requests whose request_id was generated by the library rather than given
+	// by the caller. The generated ID is left on the request, so every attempt still sends the same one
+	GeneratedKeys bool
+}
+
+// retryable reports whether the failed attempt of the request may be sent again.
+func (conf *Config) retryable(err error) bool {
+	if conf.Options == nil || conf.Options.Retry == nil || err == nil {
+		return false
+	}
+	if conf.Method != http.MethodGet && conf.Method != http.MethodHead {
+		if conf.IdempotencyKey == "" || (conf.GeneratedKey && !conf.Options.Retry.GeneratedKeys) {
+			return false
+		}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoff returns the delay before the given retry, counted from 1.
+func (conf *Config) backoff(retry int, err error) time.Duration {
+	d := conf.Options.Retry.Backoff
+	if d == 0 {
+		d = 500 * time.Millisecond
+	}
+	d <<= retry - 1
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		now := time.Now()
+		if rl := ParseRateLimit(apiErr.StatusCode, apiErr.Header, now); rl != nil && rl.Reset.Sub(now) > d {
+			d = rl.Reset.Sub(now)
+		}
+	}
+	return d
+}
+
+// sleep waits for d unless the context is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
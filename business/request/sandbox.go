@@ -0,0 +1,54 @@
+package request
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+var (
+	sandboxMu sync.RWMutex
+	// sandboxHosts maps every production host to the host serving its sandbox environment
+	sandboxHosts = map[string]string{
+		"b2b.revolut.com":      "sandbox-b2b.revolut.com",
+		"business.revolut.com": "sandbox-business.revolut.com",
+	}
+)
+
+// SetSandboxHost overrides (or adds) the sandbox host used in place of the given production host.
+func SetSandboxHost(host, sandboxHost string) {
+	sandboxMu.Lock()
+	defer sandboxMu.Unlock()
+
+	sandboxHosts[host] = sandboxHost
+}
+
+// SandboxUrl rewrites a production url to its sandbox equivalent.
+// It fails when the host has no sandbox mapping instead of silently falling back to production.
+func SandboxUrl(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+
+	sandboxMu.RLock()
+	sandboxHost, ok := sandboxHosts[u.Host]
+	sandboxMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("request: no sandbox mapping for host %q", u.Host)
+	}
+
+	u.Host = sandboxHost
+	return u.String(), nil
+}
+
+// ValidateSandbox checks that every given endpoint has a sandbox mapping.
+func ValidateSandbox(endpoints ...string) error {
+	for _, endpoint := range endpoints {
+		if _, err := SandboxUrl(endpoint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
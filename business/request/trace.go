@@ -0,0 +1,57 @@
+package request
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/quiver-london/go-revolut/v2/business/request"
+
+// startSpan opens a client span for the request, or returns a no-op span when tracing is disabled.
+func (conf *Config) startSpan(ctx context.Context) (context.Context, trace.Span) {
+	if conf.Options == nil || conf.Options.TracerProvider == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	name := conf.Operation
+	if name == "" {
+		name = conf.Method
+	}
+
+	return conf.Options.TracerProvider.Tracer(tracerName).Start(ctx, "revolut."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", conf.Method),
+			attribute.String("http.url", conf.Url),
+		))
+}
+
+// endSpan records the outcome of the request on the span and ends it.
+func endSpan(span trace.Span, entry *LogEntry) {
+	span.SetAttributes(attribute.String("revolut.client_request_id", entry.ClientRequestId))
+	if entry.RequestId != "" {
+		span.SetAttributes(attribute.String("revolut.request_id", entry.RequestId))
+	}
+	if entry.TraceId != "" {
+		span.SetAttributes(attribute.String("revolut.trace_id", entry.TraceId))
+	}
+	if entry.ActingUser != nil {
+		span.SetAttributes(attribute.String("revolut.acting_user", entry.ActingUser.Id))
+	}
+	if entry.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", entry.StatusCode))
+	}
+
+	switch {
+	case entry.Err != nil:
+		span.RecordError(entry.Err)
+		span.SetStatus(codes.Error, entry.Err.Error())
+	case entry.StatusCode >= 400:
+		span.SetStatus(codes.Error, entry.ResponseBody)
+	}
+
+	span.End()
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"net/http"
+	"time"
+)
+
+// PoolConfig tunes the connection pool of an HTTP client. Zero values keep the net/http defaults.
+type PoolConfig struct {
+	// the maximum number of idle connections across all hosts
+	MaxIdleConns int
+	// the maximum number of idle connections kept per host, net/http keeps only 2 by default
+	MaxIdleConnsPerHost int
+	// the maximum number of connections per host, including those in use
+	MaxConnsPerHost int
+	// how long an idle connection is kept open
+	IdleConnTimeout time.Duration
+	// how long establishing a connection may take, 30 seconds when zero
+	DialTimeout time.Duration
+	// how long the TLS handshake may take, 10 seconds when zero
+	TLSHandshakeTimeout time.Duration
+}
+
+// DefaultPoolConfig suits batch runs against the API, which all go to a single host.
+var DefaultPoolConfig = PoolConfig{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 32,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// defaultClient is shared by every request without an HTTP client of its own, so connections are reused
+var defaultClient = NewHTTPClient(DefaultPoolConfig)
+
+// NewHTTPClient returns an HTTP client with keep-alives enabled and its connection pool tuned by pool.
+func NewHTTPClient(pool PoolConfig) *http.Client {
+	if pool.DialTimeout == 0 {
+		pool.DialTimeout = 30 * time.Second
+	}
+	if pool.TLSHandshakeTimeout == 0 {
+		pool.TLSHandshakeTimeout = 10 * time.Second
+	}
+
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialContext(pool.DialTimeout),
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          pool.MaxIdleConns,
+		MaxIdleConnsPerHost:   pool.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       pool.MaxConnsPerHost,
+		IdleConnTimeout:       pool.IdleConnTimeout,
+		TLSHandshakeTimeout:   pool.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{Transport: t}
+}
+
+func (conf *Config) httpClient() *http.Client {
+	if conf.Options == nil || conf.Options.HTTPClient == nil {
+		return defaultClient
+	}
+	return conf.Options.HTTPClient
+}
@@ -0,0 +1,115 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// maxRequotes is how many times ExchangeWithinTolerance fetches the rate again before giving up
+const maxRequotes = 3
+
+// RateMovedError is returned by ExchangeWithinTolerance when the rate moved away from the quote by more than
+// the tolerance, or when every exchange attempted was refused for a rate change.
+type RateMovedError struct {
+	From Currency
+	To   Currency
+	// the rate the exchange was quoted at
+	Quoted float64
+	// the rate fetched after the last exchange was refused
+	Current float64
+	// the largest relative change accepted, e.g. 0.005
+	Tolerance float64
+	// the exchanges refused for a rate change
+	Attempts int
+}
+
+// Change returns the relative change of the rate from the quote, e.g. -0.01 when it fell by 1%.
+func (e *RateMovedError) Change() float64 {
+	return (e.Current - e.Quoted) / e.Quoted
+}
+
+func (e *RateMovedError) Error() string {
+	if math.Abs(e.Change()) <= e.Tolerance {
+		return fmt.Sprintf("exchange: %d %s/%s exchanges were refused for a rate change, the last rate %v being within the %0.2f%% tolerance of %v",
+			e.Attempts, e.From, e.To, e.Current, e.Tolerance*100, e.Quoted)
+	}
+	return fmt.Sprintf("exchange: the %s/%s rate moved from %v to %v (%+0.2f%%), beyond the %0.2f%% tolerance",
+		e.From, e.To, e.Quoted, e.Current, e.Change()*100, e.Tolerance*100)
+}
+
+// ExchangeWithinTolerance executes an exchange quoted at the given rate, e.g. the Rate shown to a user. When it
+// is refused because the rate moved, the rate is fetched again and the exchange retried as long as the rate stays
+// within tolerance of the quote, a relative change such as 0.005 for 0.5%; otherwise, or once the exchange was
+// refused maxRequotes+1 times, a *RateMovedError is returned. Every retry is a new exchange with a new request ID,
+// the refused one having moved no funds. Rates are fetched from the API even on a client with a rate cache.
+func (b *Client) ExchangeWithinTolerance(ctx context.Context, exchangeReq *ExchangeReq, quoted float64, tolerance float64) (*ExchangeResp, error) {
+	if quoted <= 0 {
+		return nil, invalid("quoted", "must be positive")
+	}
+	if tolerance < 0 {
+		return nil, invalid("tolerance", "cannot be negative")
+	}
+	e, err := b.exchange()
+	if err != nil {
+		return nil, err
+	}
+
+	current := quoted
+	for requotes := 0; ; requotes++ {
+		r, err := e.Exchange(ctx, exchangeReq)
+		if !rateMoved(r, err) {
+			return r, err
+		}
+		moved := &RateMovedError{
+			From:      exchangeReq.From.Currency,
+			To:        exchangeReq.To.Currency,
+			Quoted:    quoted,
+			Current:   current,
+			Tolerance: tolerance,
+			Attempts:  requotes + 1,
+		}
+		if requotes == maxRequotes {
+			return nil, moved
+		}
+
+		// the rate endpoint prices the amount sold, estimated at the quote when the amount bought is set
+		sell := exchangeReq.From.Amount
+		if sell == 0 {
+			sell = exchangeReq.To.Amount / quoted
+		}
+		rate, err := e.Rate(ctx, &ExchangeRateReq{From: exchangeReq.From.Currency, To: exchangeReq.To.Currency, Amount: sell})
+		if err != nil {
+			return nil, err
+		}
+		current = rate.Rate
+		if math.Abs(current-quoted)/quoted > tolerance {
+			moved.Current = current
+			return nil, moved
+		}
+
+		if exchangeReq.RequestId, err = e.options.NewId(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rateMoved reports whether an exchange was refused because its rate changed, either declined with
+// ReasonCode_RATE_CHANGED or rejected with an error naming the rate change.
+func rateMoved(r *ExchangeResp, err error) bool {
+	if err == nil {
+		return r != nil && r.State == PaymentState_DECLINE && r.ReasonCode == ReasonCode_RATE_CHANGED
+	}
+
+	var apiErr *request.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode >= 500 {
+		return false
+	}
+	body := strings.ToLower(apiErr.Body)
+	return strings.Contains(body, string(ReasonCode_RATE_CHANGED)) || strings.Contains(body, "rate has changed") ||
+		strings.Contains(body, "rate changed")
+}
@@ -0,0 +1,702 @@
+package revoluttest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+const (
+	// RefreshToken is the refresh token the server accepts
+	RefreshToken = "revoluttest-refresh-token"
+	// ClientId is the client ID of the clients returned by Server.Client
+	ClientId = "revoluttest-client-id"
+
+	apiPrefix = "/api/1.0/"
+)
+
+// Fixtures seed the state of a server.
+type Fixtures struct {
+	Accounts       []*business.AccountResp
+	Counterparties []*business.CounterpartyResp
+	// the existing transactions, in any order
+	Transactions []*business.TransactionResp
+	// the exchange rates by "FROM/TO" pair; the inverse pair is derived when missing
+	Rates map[string]float64
+	// the fee of an exchange as a fraction of the amount sent, e.g. 0.005
+	ExchangeFee float64
+	// the lifetime of the access tokens issued, 40 minutes when zero
+	TokenTTL time.Duration
+	// whether payments are created pending, to be moved on by the sandbox simulation endpoints
+	PendingPayments bool
+	// whether payments are created pending approval, to be approved or rejected with Approve and Reject
+	PaymentApproval bool
+}
+
+// Server is an in-process emulation of the main Business API endpoints: token, revocation, accounts, counterparties,
+// rate, exchange, pay, transactions and the sandbox top-up and simulation endpoints. Payments and exchanges move
+// the balances of the fixture accounts, are deduplicated by request_id and are listed with the other transactions.
+// Declining, failing or reverting a payment gives its funds back.
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	accounts       []*business.AccountResp
+	counterparties []*business.CounterpartyResp
+	transactions   []*business.TransactionResp
+	rates          map[string]float64
+	fee            float64
+	tokenTTL       time.Duration
+	pending        bool
+	approval       bool
+	tokens         map[string]time.Time
+	revoked        bool
+	issued         int
+	served         int
+	calls          map[string]int
+}
+
+// NewServer starts a server seeded with the fixtures. Close it when done.
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{
+		counterparties: fixtures.Counterparties,
+		transactions:   append([]*business.TransactionResp{}, fixtures.Transactions...),
+		rates:          fixtures.Rates,
+		fee:            fixtures.ExchangeFee,
+		tokenTTL:       fixtures.TokenTTL,
+		pending:        fixtures.PendingPayments,
+		approval:       fixtures.PaymentApproval,
+		tokens:         map[string]time.Time{},
+		calls:          map[string]int{},
+	}
+	// balances move, so the server works on copies of the fixture accounts
+	for _, a := range fixtures.Accounts {
+		c := *a
+		s.accounts = append(s.accounts, &c)
+	}
+	if s.tokenTTL == 0 {
+		s.tokenTTL = 40 * time.Minute
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// HTTPClient returns an HTTP client sending every request, whatever its host, to the server.
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{Transport: &rewrite{host: s.Listener.Addr().String(), next: s.Server.Client().Transport}}
+}
+
+// Client returns a business client talking to the server, with a generated private key.
+func (s *Server) Client(opts ...business.Option) (*business.Client, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]business.Option{business.WithHTTPClient(s.HTTPClient())}, opts...)
+	return business.NewClient(ClientId, RefreshToken, key, "revoluttest.local", false, opts...)
+}
+
+// SandboxClient returns a sandbox business client talking to the server, whose Sandbox service drives the
+// transactions of the server.
+func (s *Server) SandboxClient(opts ...business.Option) (*business.Client, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]business.Option{business.WithHTTPClient(s.HTTPClient())}, opts...)
+	return business.NewClient(ClientId, RefreshToken, key, "revoluttest.local", true, opts...)
+}
+
+// Calls returns how many requests the server answered for an operation, e.g. "POST /pay".
+func (s *Server) Calls(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[operation]
+}
+
+// Account returns a copy of the current state of an account, nil when unknown.
+func (s *Server) Account(id string) *business.AccountResp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.account(id)
+	if a == nil {
+		return nil
+	}
+	c := *a
+	return &c
+}
+
+// Transactions returns the transactions of the server, newest first.
+func (s *Server) Transactions() []*business.TransactionResp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sorted()
+}
+
+// Approve approves a payment pending approval, which then moves on as if it had just been created: pending with
+// PendingPayments, completed otherwise. It reports whether the payment was pending approval.
+func (s *Server) Approve(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	to := business.PaymentState_COMPLETE
+	if s.pending {
+		to = business.PaymentState_PENDING
+	}
+	return s.decide(id, to)
+}
+
+// Reject rejects a payment pending approval, which is declined and gives its funds back. It reports whether the
+// payment was pending approval.
+func (s *Server) Reject(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.decide(id, business.PaymentState_DECLINE)
+}
+
+func (s *Server) decide(id string, to business.PaymentState) bool {
+	for _, tx := range s.transactions {
+		if tx.Id != id || tx.State != business.PaymentState_PENDING_APPROVAL {
+			continue
+		}
+		s.move(tx, to)
+		return true
+	}
+	return false
+}
+
+type rewrite struct {
+	host string
+	next http.RoundTripper
+}
+
+func (r *rewrite) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host, req.Host = "http", r.host, r.host
+	return r.next.RoundTrip(req)
+}
+
+// apiError mirrors the error body of the API.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.served++
+	s.calls[r.Method+" /"+path[0]]++
+	w.Header().Set(request.RequestIdHeader, fmt.Sprintf("revoluttest-%d", s.served))
+
+	if path[0] == "auth" && len(path) == 2 && path[1] == "revoke" {
+		s.revoke(w, r)
+		return
+	}
+	if path[0] == "auth" {
+		s.token(w, r)
+		return
+	}
+	if !s.authorised(r) {
+		writeError(w, http.StatusUnauthorized, "The request should be authorized.")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path[0] == "accounts" && len(path) == 1:
+		writeJSON(w, http.StatusOK, s.accounts)
+	case r.Method == http.MethodGet && path[0] == "accounts" && len(path) == 2:
+		if a := s.account(path[1]); a != nil {
+			writeJSON(w, http.StatusOK, a)
+			return
+		}
+		writeError(w, http.StatusNotFound, "Account not found")
+	case r.Method == http.MethodGet && path[0] == "accounts" && len(path) == 3 && path[2] == "bank-details":
+		writeJSON(w, http.StatusOK, []*business.AccountDetailResp{})
+	case r.Method == http.MethodGet && path[0] == "counterparties":
+		writeJSON(w, http.StatusOK, s.counterparties)
+	case r.Method == http.MethodGet && path[0] == "counterparty" && len(path) == 2:
+		for _, c := range s.counterparties {
+			if c.Id == path[1] {
+				writeJSON(w, http.StatusOK, c)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Counterparty not found")
+	case r.Method == http.MethodDelete && path[0] == "counterparty" && len(path) == 2:
+		for i, c := range s.counterparties {
+			if c.Id == path[1] {
+				s.counterparties = append(s.counterparties[:i:i], s.counterparties[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Counterparty not found")
+	case r.Method == http.MethodGet && path[0] == "rate":
+		s.rate(w, r)
+	case r.Method == http.MethodPost && path[0] == "exchange":
+		s.exchange(w, r)
+	case r.Method == http.MethodPost && path[0] == "pay":
+		s.pay(w, r)
+	case r.Method == http.MethodGet && path[0] == "transactions":
+		s.list(w, r)
+	case r.Method == http.MethodGet && path[0] == "transaction" && len(path) == 2:
+		for _, tx := range s.transactions {
+			if tx.Id == path[1] || (r.URL.Query().Get("id_type") == "request_id" && tx.RequestId == path[1]) {
+				writeJSON(w, http.StatusOK, tx)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Transaction not found")
+	case r.Method == http.MethodPost && path[0] == "sandbox" && len(path) == 2 && path[1] == "topup":
+		s.topUp(w, r)
+	case r.Method == http.MethodPost && path[0] == "sandbox" && len(path) == 4 && path[1] == "transactions":
+		s.simulate(w, path[2], business.SimulationAction(path[3]))
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("client_assertion") == "" {
+		writeError(w, http.StatusBadRequest, "invalid_client")
+		return
+	}
+
+	resp := business.OAuthResp{TokenType: "bearer", ExpiresIn: int32(s.tokenTTL / time.Second)}
+	switch r.PostForm.Get("grant_type") {
+	case "refresh_token":
+		if r.PostForm.Get("refresh_token") != RefreshToken || s.revoked {
+			writeError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+	case "authorization_code":
+		resp.RefreshToken = RefreshToken
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	s.issued++
+	resp.AccessToken = fmt.Sprintf("revoluttest-access-token-%d", s.issued)
+	s.tokens[resp.AccessToken] = time.Now().Add(s.tokenTTL)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// revoke invalidates an access token, or the refresh token and every access token issued with it.
+func (s *Server) revoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("client_assertion") == "" {
+		writeError(w, http.StatusBadRequest, "invalid_client")
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == RefreshToken {
+		s.revoked = true
+		s.tokens = map[string]time.Time{}
+	}
+	delete(s.tokens, token)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) authorised(r *http.Request) bool {
+	expiry, ok := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Server) rate(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, to := q.Get("from"), q.Get("to")
+	// like the API, a missing amount quotes 1 and an explicit zero is refused
+	amount := 1.0
+	if v := q.Get("amount"); v != "" {
+		var err error
+		if amount, err = strconv.ParseFloat(v, 64); err != nil || amount <= 0 {
+			writeError(w, http.StatusBadRequest, "amount must be positive")
+			return
+		}
+	}
+
+	rate, ok := s.rateOf(from, to)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Exchange from %s to %s is not supported", from, to))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, business.ExchangeRateResp{
+		From:     business.Amount{Amount: amount, Currency: from},
+		To:       business.Amount{Amount: round(amount * rate), Currency: to},
+		Rate:     rate,
+		Fee:      business.Amount{Amount: round(amount * s.fee), Currency: from},
+		RateDate: business.Time{Time: time.Now().UTC()},
+	})
+}
+
+func (s *Server) rateOf(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	if rate, ok := s.rates[from+"/"+to]; ok {
+		return rate, true
+	}
+	if rate, ok := s.rates[to+"/"+from]; ok && rate != 0 {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+func (s *Server) exchange(w http.ResponseWriter, r *http.Request) {
+	req := &business.ExchangeReq{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if tx := s.byRequestId(req.RequestId); tx != nil {
+		writeJSON(w, http.StatusOK, exchangeResp(tx))
+		return
+	}
+
+	from, to := s.account(req.From.AccountId), s.account(req.To.AccountId)
+	if from == nil || to == nil {
+		writeError(w, http.StatusNotFound, "Account not found")
+		return
+	}
+	rate, ok := s.rateOf(string(req.From.Currency), string(req.To.Currency))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Exchange is not supported")
+		return
+	}
+
+	sell, buy := req.From.Amount, req.To.Amount
+	if sell == 0 {
+		sell = round(buy / rate)
+	} else {
+		buy = round(sell * rate)
+	}
+	fee := round(sell * s.fee)
+	if from.Balance < sell+fee {
+		writeError(w, http.StatusBadRequest, "Insufficient balance")
+		return
+	}
+	from.Balance = round(from.Balance - sell - fee)
+	to.Balance = round(to.Balance + buy)
+
+	tx := s.record(business.PaymentType_EXCHANGE, req.RequestId, req.Reference,
+		business.TransactionLeg{AccountId: from.Id, Amount: -sell, Fee: fees(fee), Currency: from.Currency, Balance: float(from.Balance), Description: "Exchange"},
+		business.TransactionLeg{AccountId: to.Id, Amount: buy, Currency: to.Currency, Balance: float(to.Balance), Description: "Exchange"},
+	)
+	writeJSON(w, http.StatusOK, exchangeResp(tx))
+}
+
+func exchangeResp(tx *business.TransactionResp) *business.ExchangeResp {
+	return &business.ExchangeResp{
+		Id:          tx.Id,
+		State:       tx.State,
+		ReasonCode:  tx.ReasonCode,
+		CreatedAt:   tx.CreatedAt,
+		CompletedAt: tx.CompletedAt,
+	}
+}
+
+func (s *Server) pay(w http.ResponseWriter, r *http.Request) {
+	req := &business.PaymentReq{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if tx := s.byRequestId(req.RequestId); tx != nil {
+		writeJSON(w, http.StatusOK, tx)
+		return
+	}
+
+	from := s.account(req.AccountId)
+	if from == nil {
+		writeError(w, http.StatusNotFound, "Account not found")
+		return
+	}
+	if from.Currency != req.Currency {
+		writeError(w, http.StatusBadRequest, "Currency mismatch")
+		return
+	}
+	if from.Balance < req.Amount {
+		writeError(w, http.StatusBadRequest, "Insufficient balance")
+		return
+	}
+	from.Balance = round(from.Balance - req.Amount)
+
+	legs := []business.TransactionLeg{{
+		AccountId:    from.Id,
+		Counterparty: business.LegCounterparty{Id: req.Receiver.CounterpartyId, AccountId: req.Receiver.AccountId, CardId: req.Receiver.CardId, Type: business.CounterpartyType_EXTERNAL},
+		Amount:       -req.Amount,
+		Currency:     req.Currency,
+		Balance:      float(from.Balance),
+		Description:  "Payment",
+	}}
+	// paying one of the server's own accounts credits it
+	if to := s.account(req.Receiver.AccountId); to != nil {
+		legs[0].Counterparty.Type = business.CounterpartyType_SELF
+		to.Balance = round(to.Balance + req.Amount)
+		legs = append(legs, business.TransactionLeg{AccountId: to.Id, Amount: req.Amount, Currency: to.Currency, Balance: float(to.Balance), Description: "Payment"})
+	}
+
+	tx := s.record(business.PaymentType_TRANSFER, req.RequestId, req.Reference, legs...)
+	switch {
+	case s.approval:
+		tx.State, tx.CompletedAt = business.PaymentState_PENDING_APPROVAL, nil
+	case s.pending:
+		tx.State, tx.CompletedAt = business.PaymentState_PENDING, nil
+	}
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) topUp(w http.ResponseWriter, r *http.Request) {
+	req := &business.TopUpReq{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	to := s.account(req.AccountId)
+	if to == nil {
+		writeError(w, http.StatusNotFound, "Account not found")
+		return
+	}
+	if to.Currency != req.Currency {
+		writeError(w, http.StatusBadRequest, "Currency mismatch")
+		return
+	}
+	to.Balance = round(to.Balance + req.Amount)
+
+	tx := s.record(business.PaymentType_TOPUP, "", req.Reference,
+		business.TransactionLeg{AccountId: to.Id, Amount: req.Amount, Currency: to.Currency, Balance: float(to.Balance), Description: "Top-up"},
+	)
+	if req.State != "" && req.State != business.PaymentState_COMPLETE {
+		tx.State, tx.CompletedAt = req.State, nil
+	}
+	writeJSON(w, http.StatusOK, simulationResp(tx))
+}
+
+// simulate moves a transaction on like the sandbox: pending ones can be completed, declined or failed, completed
+// ones reverted. The funds of the transactions declined, failed or reverted are given back.
+func (s *Server) simulate(w http.ResponseWriter, id string, action business.SimulationAction) {
+	var tx *business.TransactionResp
+	for _, t := range s.transactions {
+		if t.Id == id {
+			tx = t
+		}
+	}
+	if tx == nil {
+		writeError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	from, to := business.PaymentState_PENDING, business.PaymentState("")
+	switch action {
+	case business.SimulationAction_COMPLETE:
+		to = business.PaymentState_COMPLETE
+	case business.SimulationAction_DECLINE:
+		to = business.PaymentState_DECLINE
+	case business.SimulationAction_FAIL:
+		to = business.PaymentState_FAILED
+	case business.SimulationAction_REVERT:
+		from, to = business.PaymentState_COMPLETE, business.PaymentState_REVERTED
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if tx.State != from {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Transaction in state %s cannot be moved to %s", tx.State, to))
+		return
+	}
+
+	s.move(tx, to)
+	writeJSON(w, http.StatusOK, simulationResp(tx))
+}
+
+// move sets the state of a transaction, giving its funds back unless it completed or is still pending
+func (s *Server) move(tx *business.TransactionResp, to business.PaymentState) {
+	now := business.Time{Time: time.Now().UTC()}
+	tx.State, tx.UpdatedAt = to, now
+	switch to {
+	case business.PaymentState_COMPLETE:
+		tx.CompletedAt = &now
+	case business.PaymentState_PENDING:
+	default:
+		for _, leg := range tx.Legs {
+			if a := s.account(leg.AccountId); a != nil {
+				a.Balance = round(a.Balance - leg.Amount)
+			}
+		}
+	}
+}
+
+func simulationResp(tx *business.TransactionResp) *business.SimulationResp {
+	r := &business.SimulationResp{Id: tx.Id, State: tx.State, CreatedAt: tx.CreatedAt.Format(time.RFC3339Nano)}
+	if tx.CompletedAt != nil {
+		r.CompletedAt = tx.CompletedAt.Format(time.RFC3339Nano)
+	}
+	return r
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	count := 100
+	if c, err := strconv.Atoi(q.Get("count")); err == nil && c > 0 {
+		count = c
+	}
+	from, _ := parseTime(q.Get("from"))
+	to, _ := parseTime(q.Get("to"))
+
+	page := []*business.TransactionResp{}
+	for _, tx := range s.sorted() {
+		if !from.IsZero() && tx.CreatedAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && tx.CreatedAt.After(to) {
+			continue
+		}
+		if t := q.Get("type"); t != "" && string(tx.Type) != t {
+			continue
+		}
+		if c := q.Get("counterparty"); c != "" && !hasCounterparty(tx, c) {
+			continue
+		}
+		page = append(page, tx)
+		if len(page) == count {
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func hasCounterparty(tx *business.TransactionResp, id string) bool {
+	for _, leg := range tx.Legs {
+		if leg.Counterparty.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+func (s *Server) record(typ business.PaymentType, requestId, reference string, legs ...business.TransactionLeg) *business.TransactionResp {
+	now := business.Time{Time: time.Now().UTC()}
+	id, _ := request.UUIDGenerator{}.NewId()
+	for i := range legs {
+		legs[i].LegId, _ = request.UUIDGenerator{}.NewId()
+	}
+
+	tx := &business.TransactionResp{
+		Id:          id,
+		Type:        typ,
+		RequestId:   requestId,
+		State:       business.PaymentState_COMPLETE,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CompletedAt: &now,
+		Reference:   reference,
+		Legs:        legs,
+	}
+	s.transactions = append(s.transactions, tx)
+	return tx
+}
+
+func (s *Server) account(id string) *business.AccountResp {
+	for _, a := range s.accounts {
+		if a.Id == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Server) byRequestId(requestId string) *business.TransactionResp {
+	if requestId == "" {
+		return nil
+	}
+	for _, tx := range s.transactions {
+		if tx.RequestId == requestId {
+			return tx
+		}
+	}
+	return nil
+}
+
+func (s *Server) sorted() []*business.TransactionResp {
+	r := append([]*business.TransactionResp{}, s.transactions...)
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].CreatedAt.After(r[j].CreatedAt.Time)
+	})
+	return r
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Code: status * 10, Message: message})
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+// fees returns the fee of a leg, nil when none was charged
+func fees(v float64) *float64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,198 @@
+package revoluttest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func fixtures() revoluttest.Fixtures {
+	return revoluttest.Fixtures{
+		Accounts: []*business.AccountResp{
+			{Id: "gbp", Currency: "GBP", Balance: 100},
+			{Id: "eur", Currency: "EUR", Balance: 0},
+		},
+		Counterparties: []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+		Rates:          map[string]float64{"GBP/EUR": 1.2},
+		ExchangeFee:    0.01,
+	}
+}
+
+func TestServerPay(t *testing.T) {
+	s := revoluttest.NewServer(fixtures())
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payments, err := c.Payment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	req := &business.PaymentReq{RequestId: "pay-1", AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: 30, Currency: "GBP"}
+	tx, err := payments.Create(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same request ID returns the same transaction
+	again, err := payments.Create(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Id != tx.Id || tx.State != business.PaymentState_COMPLETE {
+		t.Errorf("got %s %s then %s, want one completed transaction", tx.Id, tx.State, again.Id)
+	}
+	if got := s.Account("gbp").Balance; got != 70 {
+		t.Errorf("got a balance of %v, want 70", got)
+	}
+	if leg := tx.Legs[0]; leg.Amount != -30 || leg.Balance == nil || *leg.Balance != 70 {
+		t.Errorf("got leg %+v, want -30 leaving 70", leg)
+	}
+
+	req = &business.PaymentReq{RequestId: "pay-2", AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: 100, Currency: "GBP"}
+	if _, err := payments.Create(ctx, req); err == nil || !strings.Contains(err.Error(), "Insufficient balance") {
+		t.Errorf("got %v, want an insufficient balance", err)
+	}
+	if n := s.Calls("POST /pay"); n != 3 {
+		t.Errorf("got %d calls to pay, want 3", n)
+	}
+	if txs := s.Transactions(); len(txs) != 1 || txs[0].Id != tx.Id {
+		t.Errorf("got transactions %+v, want the payment only", txs)
+	}
+}
+
+func TestServerExchange(t *testing.T) {
+	s := revoluttest.NewServer(fixtures())
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange, err := c.Exchange()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = exchange.Exchange(context.Background(), &business.ExchangeReq{
+		From: business.ExchangeAmount{AccountId: "gbp", Currency: "GBP", Amount: 50},
+		To:   business.ExchangeAmount{AccountId: "eur", Currency: "EUR"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 50 sold at 1.2 and a fee of 1%
+	if gbp, eur := s.Account("gbp").Balance, s.Account("eur").Balance; gbp != 49.5 || eur != 60 {
+		t.Errorf("got balances of %v GBP and %v EUR, want 49.5 and 60", gbp, eur)
+	}
+}
+
+func TestServerSimulate(t *testing.T) {
+	f := fixtures()
+	f.PendingPayments = true
+	s := revoluttest.NewServer(f)
+	defer s.Close()
+	c, err := s.SandboxClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payments, err := c.Payment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	pay := func(requestId string) *business.TransactionResp {
+		tx, err := payments.Create(ctx, &business.PaymentReq{RequestId: requestId, AccountId: "gbp", Receiver: business.PaymentReceiver{CounterpartyId: "supplier"}, Amount: 10, Currency: "GBP"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.State != business.PaymentState_PENDING {
+			t.Fatalf("got a payment %s, want it pending", tx.State)
+		}
+		return tx
+	}
+
+	completed := pay("pay-1")
+	if r, err := sandbox.Simulate(ctx, completed.Id, business.SimulationAction_COMPLETE); err != nil || r.State != business.PaymentState_COMPLETE {
+		t.Fatalf("got %+v, %v, want the payment completed", r, err)
+	}
+	declined := pay("pay-2")
+	if _, err := sandbox.Simulate(ctx, declined.Id, business.SimulationAction_DECLINE); err != nil {
+		t.Fatal(err)
+	}
+	// a declined payment gives its funds back
+	if got := s.Account("gbp").Balance; got != 90 {
+		t.Errorf("got a balance of %v, want 90", got)
+	}
+	if _, err := sandbox.Simulate(ctx, declined.Id, business.SimulationAction_COMPLETE); err == nil {
+		t.Error("got a declined payment completed")
+	}
+	if _, err := sandbox.Simulate(ctx, completed.Id, business.SimulationAction_REVERT); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Account("gbp").Balance; got != 100 {
+		t.Errorf("got a balance of %v after the revert, want 100", got)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	s := revoluttest.NewServer(fixtures())
+	defer s.Close()
+	golden := filepath.Join(t.TempDir(), "accounts.json")
+	ctx := context.Background()
+
+	list := func(mode revoluttest.Mode) (*revoluttest.Recorder, []*business.AccountResp) {
+		rec, err := revoluttest.NewRecorder(golden, mode, s.HTTPClient().Transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := s.Client(business.WithHTTPClient(rec.HTTPClient()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := c.Account()
+		if err != nil {
+			t.Fatal(err)
+		}
+		accounts, err := account.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec, accounts
+	}
+
+	rec, recorded := list(revoluttest.Mode_RECORD)
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), revoluttest.RefreshToken) {
+		t.Errorf("got the refresh token in the golden file:\n%s", b)
+	}
+	served := s.Calls("GET /accounts")
+
+	rec, replayed := list(revoluttest.Mode_REPLAY)
+	if len(replayed) != len(recorded) || replayed[0].Id != recorded[0].Id {
+		t.Errorf("got %+v replayed, want %+v", replayed, recorded)
+	}
+	if s.Calls("GET /accounts") != served {
+		t.Error("got the replay sent to the server")
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("got unused interactions %+v", unused)
+	}
+}
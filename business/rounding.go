@@ -0,0 +1,119 @@
+package business
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type RoundingMode string
+
+const (
+	// round to the nearest value, halves away from zero
+	RoundingMode_HALF_UP RoundingMode = "half_up"
+	// round to the nearest value, halves to the even neighbour (banker's rounding)
+	RoundingMode_HALF_EVEN RoundingMode = "half_even"
+	// drop the extra decimals
+	RoundingMode_TRUNCATE RoundingMode = "truncate"
+)
+
+// Round rounds the amount to the given number of decimals.
+// The amount is taken at its shortest decimal representation, so 2.675 rounds half up to 2.68
+// even though its binary value is slightly below 2.675.
+func (m RoundingMode) Round(amount float64, decimals int) float64 {
+	units, ok := m.units(amount, decimals)
+	if !ok {
+		return amount
+	}
+
+	f, _ := new(big.Rat).SetFrac(units, pow10(decimals)).Float64()
+	return f
+}
+
+// Split divides the amount into parts that add up exactly to the rounded amount,
+// spreading the remainder one minor unit at a time over the first parts.
+func (m RoundingMode) Split(amount float64, parts, decimals int) []float64 {
+	if parts < 1 {
+		return nil
+	}
+
+	units, ok := m.units(amount, decimals)
+	if !ok {
+		return nil
+	}
+
+	n := big.NewInt(int64(parts))
+	base, rem := new(big.Int).QuoRem(units, n, new(big.Int))
+	step := big.NewInt(int64(rem.Sign()))
+	rem.Abs(rem)
+
+	r := make([]float64, parts)
+	for i := range r {
+		u := new(big.Int).Set(base)
+		if int64(i) < rem.Int64() {
+			u.Add(u, step)
+		}
+		r[i], _ = new(big.Rat).SetFrac(u, pow10(decimals)).Float64()
+	}
+
+	return r
+}
+
+// units returns the amount rounded to an integer number of 10^-decimals units.
+func (m RoundingMode) units(amount float64, decimals int) (*big.Int, bool) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'g', -1, 64))
+	if !ok {
+		return nil, false
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 || m == RoundingMode_TRUNCATE {
+		return q, true
+	}
+
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	cmp := twice.Cmp(r.Denom())
+	if cmp > 0 || (cmp == 0 && (m != RoundingMode_HALF_EVEN || q.Bit(0) == 1)) {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	return q, true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Rounding applies a rounding mode and records every rounding in the audit log.
+type Rounding struct {
+	Mode RoundingMode
+	// an optional audit logger
+	Audit AuditLogger
+}
+
+// Round rounds the amount for the given operation and audits the rounding when it changed the amount.
+// The acting user of the context, if any, is recorded with the audit entry.
+func (r *Rounding) Round(ctx context.Context, operation string, amount float64, decimals int) float64 {
+	rounded := r.Mode.Round(amount, decimals)
+	if r.Audit != nil && rounded != amount {
+		r.Audit.Audit(&AuditEntry{
+			Time:       time.Now(),
+			Action:     "round",
+			Operation:  operation,
+			ActingUser: request.ActingUserFromContext(ctx),
+			Details: map[string]string{
+				"mode":     string(r.Mode),
+				"decimals": strconv.Itoa(decimals),
+				"amount":   strconv.FormatFloat(amount, 'g', -1, 64),
+				"rounded":  strconv.FormatFloat(rounded, 'f', decimals, 64),
+			},
+		})
+	}
+
+	return rounded
+}
@@ -0,0 +1,127 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// errNotSandbox is returned when asking a production client for the simulation endpoints
+var errNotSandbox = errors.New("sandbox: simulation endpoints are only available in the sandbox")
+
+type SandboxService struct {
+	accessToken string
+	sandbox     bool
+	options     *request.Options
+}
+
+type TopUpReq struct {
+	// the ID of the account to fund
+	AccountId string `json:"account_id"`
+	// the amount to add
+	Amount float64 `json:"amount"`
+	// the account currency
+	Currency string `json:"currency"`
+	// an optional textual reference shown on the transaction
+	Reference string `json:"reference,omitempty"`
+	// an optional state of the top-up transaction, completed by default
+	State PaymentState `json:"state,omitempty"`
+}
+
+type SimulationAction string
+
+const (
+	SimulationAction_COMPLETE SimulationAction = "complete"
+	SimulationAction_REVERT   SimulationAction = "revert"
+	SimulationAction_DECLINE  SimulationAction = "decline"
+	SimulationAction_FAIL     SimulationAction = "fail"
+)
+
+// simulationActions are the actions moving a transaction into each state
+var simulationActions = map[PaymentState]SimulationAction{
+	PaymentState_COMPLETE: SimulationAction_COMPLETE,
+	PaymentState_REVERTED: SimulationAction_REVERT,
+	PaymentState_DECLINE:  SimulationAction_DECLINE,
+	PaymentState_FAILED:   SimulationAction_FAIL,
+}
+
+type SimulationResp struct {
+	// the ID of the transaction
+	Id string `json:"id"`
+	// the state of the transaction after the simulation
+	State PaymentState `json:"state"`
+	// the instant when the transaction was created
+	CreatedAt string `json:"created_at"`
+	// the instant when the transaction was completed
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// TopUp: Fund a sandbox account, the top-up shows as a topup transaction.
+// doc: https://developer.revolut.com/docs/business/top-up-account
+func (s *SandboxService) TopUp(ctx context.Context, topUpReq *TopUpReq) (*SimulationResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "sandbox.top_up",
+		Method:      http.MethodPost,
+		Url:         endpoint(nil, "sandbox", "topup"),
+		AccessToken: s.accessToken,
+		Sandbox:     s.sandbox,
+		Options:     s.options,
+		Context:     ctx,
+		Body:        topUpReq,
+		ContentType: request.ContentType_APPLICATION_JSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &SimulationResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Simulate: Move a sandbox transaction to another state, e.g. complete a pending payment or revert a completed one.
+// doc: https://developer.revolut.com/docs/business/simulate-transfer-state-change
+func (s *SandboxService) Simulate(ctx context.Context, id string, action SimulationAction) (*SimulationResp, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "sandbox.simulate",
+		Method:      http.MethodPost,
+		Url:         endpoint(nil, "sandbox", "transactions", id, string(action)),
+		AccessToken: s.accessToken,
+		Sandbox:     s.sandbox,
+		Options:     s.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &SimulationResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ForceState: Move a sandbox transaction into the given state, one of completed, reverted, declined or failed, see Simulate.
+// Pending transactions can be completed, declined or failed, completed ones can be reverted.
+// doc: https://developer.revolut.com/docs/business/simulate-transfer-state-change
+func (s *SandboxService) ForceState(ctx context.Context, id string, state PaymentState) (*SimulationResp, error) {
+	action, ok := simulationActions[state]
+	if !ok {
+		return nil, errors.New("sandbox: a transaction cannot be moved into the " + string(state) + " state")
+	}
+	return s.Simulate(ctx, id, action)
+}
@@ -0,0 +1,103 @@
+package business
+
+import (
+	"context"
+	"io"
+)
+
+// The interfaces below are implemented by the services of the same name and returned by Client,
+// so consumers can mock the API, e.g. with gomock or mockery, without wrapping the SDK.
+
+// AccountReader reads the accounts of the business.
+type AccountReader interface {
+	List(ctx context.Context) ([]*AccountResp, error)
+	WithId(ctx context.Context, id string) (*AccountResp, error)
+	DetailWithId(ctx context.Context, id string) ([]*AccountDetailResp, error)
+	Statement(ctx context.Context, statementReq *StatementReq, w io.Writer) (int64, error)
+	BalancesByCurrency(ctx context.Context) (map[string]*CurrencyBalance, error)
+}
+
+// CounterpartyManager manages the counterparties of the business.
+type CounterpartyManager interface {
+	AddRevolut(ctx context.Context, revolutCounterparty *RevolutCounterpartyReq) (*CounterpartyResp, error)
+	AddNonRevolut(ctx context.Context, nonRevolutCounterparty *NonRevolutCounterpartyReq) (*CounterpartyResp, error)
+	Delete(ctx context.Context, id string) error
+	WithId(ctx context.Context, id string) (*CounterpartyResp, error)
+	List(ctx context.Context) ([]*CounterpartyResp, error)
+	ListAll(ctx context.Context) *Pager[*CounterpartyResp]
+	FindByIban(ctx context.Context, iban string) (*CounterpartyResp, error)
+	FindByEmail(ctx context.Context, email string) (*CounterpartyResp, error)
+	ValidateAccountName(ctx context.Context, accountNameReq *AccountNameReq) (*AccountNameResp, error)
+}
+
+// Transferrer moves money between accounts of the business.
+type Transferrer interface {
+	Create(ctx context.Context, transferReq *TransferReq) (*TransferResp, error)
+	Reasons(ctx context.Context) ([]*TransferReason, error)
+}
+
+// Payer creates payments and reads transactions.
+type Payer interface {
+	Create(ctx context.Context, paymentReq *PaymentReq) (*TransactionResp, error)
+	WithId(ctx context.Context, id string) (*TransactionResp, error)
+	WaitForCompletion(ctx context.Context, id string) (*TransactionResp, error)
+	WaitForApproval(ctx context.Context, id string) (*TransactionResp, error)
+	WithRequestId(ctx context.Context, requestId string) (*TransactionResp, error)
+	Cancel(ctx context.Context, id string) error
+	List(ctx context.Context, transactionReq *TransactionReq) ([]*TransactionResp, error)
+	ListEach(ctx context.Context, transactionReq *TransactionReq, fn func(*TransactionResp) error) error
+	ListAll(ctx context.Context, transactionReq *TransactionReq) *Pager[*TransactionResp]
+	ListScheduled(ctx context.Context, transactionReq *TransactionReq) ([]*ScheduledPayment, error)
+	CancelScheduled(ctx context.Context, transactionReq *TransactionReq, keep func(*ScheduledPayment) bool) ([]*ScheduledPayment, error)
+}
+
+// PaymentDrafter manages payment drafts.
+type PaymentDrafter interface {
+	Create(ctx context.Context, paymentDraftReq *PaymentDraftReq) (*PaymentDraftResp, error)
+	List(ctx context.Context) (*PaymentDrafts, error)
+	WithId(ctx context.Context, id string) (*PaymentDraftDetailPayment, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Exchanger quotes and exchanges currencies.
+type Exchanger interface {
+	Rate(ctx context.Context, exchangeRateReq *ExchangeRateReq) (*ExchangeRateResp, error)
+	Exchange(ctx context.Context, exchangeReq *ExchangeReq) (*ExchangeResp, error)
+}
+
+// WebhookManager sets up the webhook of the business.
+type WebhookManager interface {
+	Set(ctx context.Context, url string) error
+	Delete(ctx context.Context) error
+}
+
+// CardManager manages the corporate cards of the business.
+type CardManager interface {
+	List(ctx context.Context, cardListReq *CardListReq) ([]*CardResp, error)
+	ListAll(ctx context.Context) *Pager[*CardResp]
+	WithId(ctx context.Context, id string) (*CardResp, error)
+	SensitiveDetails(ctx context.Context, id string) (*CardSensitiveDetailsResp, error)
+	Freeze(ctx context.Context, id string) error
+	Unfreeze(ctx context.Context, id string) error
+	Update(ctx context.Context, id string, cardUpdateReq *CardUpdateReq) (*CardResp, error)
+	Terminate(ctx context.Context, id string) error
+}
+
+// Simulator drives sandbox accounts and transactions in end-to-end tests.
+type Simulator interface {
+	TopUp(ctx context.Context, topUpReq *TopUpReq) (*SimulationResp, error)
+	Simulate(ctx context.Context, id string, action SimulationAction) (*SimulationResp, error)
+	ForceState(ctx context.Context, id string, state PaymentState) (*SimulationResp, error)
+}
+
+var (
+	_ AccountReader       = (*AccountService)(nil)
+	_ CounterpartyManager = (*CounterpartyService)(nil)
+	_ Transferrer         = (*TransferService)(nil)
+	_ Payer               = (*PaymentService)(nil)
+	_ PaymentDrafter      = (*PaymentDraftService)(nil)
+	_ Exchanger           = (*ExchangeService)(nil)
+	_ WebhookManager      = (*WebhookService)(nil)
+	_ Simulator           = (*SandboxService)(nil)
+	_ CardManager         = (*CardService)(nil)
+)
@@ -0,0 +1,82 @@
+package business
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signerMethod is a jwt.SigningMethod signing with any crypto.Signer,
+// so client assertions can be signed by RSA or ECDSA keys held in memory, a KMS or an HSM.
+type signerMethod struct {
+	alg  string
+	hash crypto.Hash
+	// the byte size of r and s in an ECDSA signature, zero for RSA
+	keySize int
+}
+
+// signingMethodFor picks the JWS algorithm matching the public key of the signer.
+func signingMethodFor(signer crypto.Signer) (*signerMethod, error) {
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		return &signerMethod{alg: "RS256", hash: crypto.SHA256}, nil
+	case *ecdsa.PublicKey:
+		switch pub.Curve.Params().BitSize {
+		case 256:
+			return &signerMethod{alg: "ES256", hash: crypto.SHA256, keySize: 32}, nil
+		case 384:
+			return &signerMethod{alg: "ES384", hash: crypto.SHA384, keySize: 48}, nil
+		case 521:
+			return &signerMethod{alg: "ES512", hash: crypto.SHA512, keySize: 66}, nil
+		}
+		return nil, fmt.Errorf("oauth: unsupported ECDSA curve %s", pub.Curve.Params().Name)
+	default:
+		return nil, fmt.Errorf("oauth: unsupported signer key type %T", pub)
+	}
+}
+
+func (m *signerMethod) Alg() string {
+	return m.alg
+}
+
+func (m *signerMethod) Sign(signingString string, key interface{}) ([]byte, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, jwt.ErrInvalidKeyType
+	}
+
+	h := m.hash.New()
+	h.Write([]byte(signingString))
+
+	sig, err := signer.Sign(rand.Reader, h.Sum(nil), m.hash)
+	if err != nil {
+		return nil, err
+	}
+	if m.keySize == 0 {
+		return sig, nil
+	}
+
+	// crypto.Signer returns ASN.1 encoded ECDSA signatures, JWS expects r and s concatenated
+	var rs struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 2*m.keySize)
+	rs.R.FillBytes(out[:m.keySize])
+	rs.S.FillBytes(out[m.keySize:])
+
+	return out, nil
+}
+
+func (m *signerMethod) Verify(signingString string, sig []byte, key interface{}) error {
+	return errors.New("oauth: client assertions are only signed, never verified")
+}
@@ -0,0 +1,223 @@
+package soak
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// Operation is one kind of call of the mix, picked with a probability proportional to its weight.
+type Operation struct {
+	Name   string
+	Weight int
+	Run    func(ctx context.Context, c *business.Client) error
+}
+
+var (
+	Operation_LIST_ACCOUNTS = Operation{"account.list", 4, func(ctx context.Context, c *business.Client) error {
+		s, err := c.Account()
+		if err != nil {
+			return err
+		}
+		_, err = s.List(ctx)
+		return err
+	}}
+	Operation_LIST_COUNTERPARTIES = Operation{"counterparty.list", 2, func(ctx context.Context, c *business.Client) error {
+		s, err := c.Counterparty()
+		if err != nil {
+			return err
+		}
+		_, err = s.List(ctx)
+		return err
+	}}
+	Operation_LIST_TRANSACTIONS = Operation{"payment.list", 2, func(ctx context.Context, c *business.Client) error {
+		s, err := c.Payment()
+		if err != nil {
+			return err
+		}
+		_, err = s.List(ctx, &business.TransactionReq{Count: 10})
+		return err
+	}}
+	Operation_RATE = Operation{"exchange.rate", 1, func(ctx context.Context, c *business.Client) error {
+		s, err := c.Exchange()
+		if err != nil {
+			return err
+		}
+		_, err = s.Rate(ctx, &business.ExchangeRateReq{From: "GBP", To: "EUR", Amount: 100})
+		return err
+	}}
+)
+
+// DefaultMix only reads, so it can run against any environment.
+var DefaultMix = []Operation{Operation_LIST_ACCOUNTS, Operation_LIST_COUNTERPARTIES, Operation_LIST_TRANSACTIONS, Operation_RATE}
+
+// Config describes a soak run.
+type Config struct {
+	// the number of goroutines calling the API, 8 when zero
+	Goroutines int
+	// how long the run lasts, a minute when zero
+	Duration time.Duration
+	// the operations to run, DefaultMix when empty
+	Mix []Operation
+}
+
+// OperationReport holds the outcome of one operation of the mix.
+type OperationReport struct {
+	Calls  int
+	Errors int
+	// the latency percentiles of the calls
+	P50, P95, P99, Max time.Duration
+	// the first error seen, nil when every call succeeded
+	FirstErr error
+}
+
+// Report is the outcome of a soak run.
+type Report struct {
+	// how long the run actually lasted
+	Elapsed    time.Duration
+	Operations map[string]*OperationReport
+	// the number of events published by the client during the run, by name; one refresh per token expiry
+	// and no failed refresh show the token manager held under concurrency
+	Events map[string]int
+}
+
+// Calls returns the total number of calls made.
+func (r *Report) Calls() int {
+	n := 0
+	for _, op := range r.Operations {
+		n += op.Calls
+	}
+	return n
+}
+
+// Rate returns the observed number of calls per second, to compare with the client-side rate limit.
+func (r *Report) Rate() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Calls()) / r.Elapsed.Seconds()
+}
+
+// Run calls the API with the configured mix from concurrent goroutines until the duration elapses or the
+// context is done. Point the client at a revoluttest server or the sandbox, never at production. The client's rate
+// limiter, token store and other options apply as configured, so a report shows how they hold up under load.
+// Run subscribes to the client's event bus to count events; the subscription outlives the run.
+func Run(ctx context.Context, client *business.Client, conf Config) (*Report, error) {
+	if conf.Goroutines <= 0 {
+		conf.Goroutines = 8
+	}
+	if conf.Duration <= 0 {
+		conf.Duration = time.Minute
+	}
+	if len(conf.Mix) == 0 {
+		conf.Mix = DefaultMix
+	}
+	total := 0
+	for _, op := range conf.Mix {
+		if op.Weight < 0 {
+			return nil, errors.New("soak: operation weights cannot be negative")
+		}
+		total += op.Weight
+	}
+	if total == 0 {
+		return nil, errors.New("soak: the operation mix has no weight")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, conf.Duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	running := true
+	events := map[string]int{}
+	client.Events().Subscribe("", func(e business.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if running {
+			events[e.EventName()]++
+		}
+	})
+
+	samples := make([][]sample, conf.Goroutines)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for g := 0; g < conf.Goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(time.Now().UnixNano() + int64(g)))
+			for ctx.Err() == nil {
+				op := pick(conf.Mix, total, rnd)
+				t := time.Now()
+				err := op.Run(ctx, client)
+				if ctx.Err() != nil && err != nil {
+					// calls cut short by the end of the run are not failures
+					return
+				}
+				samples[g] = append(samples[g], sample{op: op.Name, latency: time.Since(t), err: err})
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	mu.Lock()
+	running = false
+	mu.Unlock()
+
+	return report(time.Since(start), samples, events), nil
+}
+
+type sample struct {
+	op      string
+	latency time.Duration
+	err     error
+}
+
+func pick(mix []Operation, total int, rnd *rand.Rand) Operation {
+	n := rnd.Intn(total)
+	for _, op := range mix {
+		if n < op.Weight {
+			return op
+		}
+		n -= op.Weight
+	}
+	return mix[len(mix)-1]
+}
+
+func report(elapsed time.Duration, samples [][]sample, events map[string]int) *Report {
+	r := &Report{Elapsed: elapsed, Operations: map[string]*OperationReport{}, Events: events}
+	latencies := map[string][]time.Duration{}
+	for _, ss := range samples {
+		for _, s := range ss {
+			op := r.Operations[s.op]
+			if op == nil {
+				op = &OperationReport{}
+				r.Operations[s.op] = op
+			}
+			op.Calls++
+			if s.err != nil {
+				op.Errors++
+				if op.FirstErr == nil {
+					op.FirstErr = s.err
+				}
+			}
+			latencies[s.op] = append(latencies[s.op], s.latency)
+		}
+	}
+
+	for name, ls := range latencies {
+		sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+		op := r.Operations[name]
+		op.P50, op.P95, op.P99, op.Max = percentile(ls, 50), percentile(ls, 95), percentile(ls, 99), ls[len(ls)-1]
+	}
+
+	return r
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	return sorted[(len(sorted)-1)*p/100]
+}
@@ -0,0 +1,77 @@
+package soak_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+	"github.com/quiver-london/go-revolut/v2/business/soak"
+)
+
+// TestRun soaks a client refreshing its token on every call with the default mix; run it with -race.
+func TestRun(t *testing.T) {
+	s := revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts:       []*business.AccountResp{{Id: "gbp", Currency: "GBP", Balance: 1000}},
+		Counterparties: []*business.CounterpartyResp{{Id: "supplier", Name: "Supplier"}},
+		Rates:          map[string]float64{"GBP/EUR": 1.17},
+		// shorter than the refresh leeway, every access token is refreshed
+		TokenTTL: time.Second,
+	})
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := soak.Run(context.Background(), c, soak.Config{Goroutines: 4, Duration: 300 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Calls() == 0 || r.Rate() <= 0 {
+		t.Fatalf("got %d calls at %.0f/s, want some", r.Calls(), r.Rate())
+	}
+	for name, op := range r.Operations {
+		if op.Errors > 0 {
+			t.Errorf("%s: %d of %d calls failed, first with %v", name, op.Errors, op.Calls, op.FirstErr)
+		}
+		if op.P50 > op.P95 || op.P95 > op.P99 || op.P99 > op.Max {
+			t.Errorf("%s: got unordered percentiles %+v", name, op)
+		}
+		if s.Calls("GET /"+resource(name)) == 0 {
+			t.Errorf("%s: the server answered no call", name)
+		}
+	}
+	if r.Events[business.EventName_TOKEN_REFRESHED] == 0 || r.Events[business.EventName_TOKEN_REFRESH_FAILED] > 0 {
+		t.Errorf("got events %v, want refreshes and no failure", r.Events)
+	}
+}
+
+// resource returns the first path segment the operation of the default mix calls
+func resource(op string) string {
+	return map[string]string{
+		"account.list":      "accounts",
+		"counterparty.list": "counterparties",
+		"payment.list":      "transactions",
+		"exchange.rate":     "rate",
+	}[op]
+}
+
+func TestRunMix(t *testing.T) {
+	noop := func(ctx context.Context, c *business.Client) error { return nil }
+	tests := []struct {
+		name string
+		mix  []soak.Operation
+	}{
+		{"negative weight", []soak.Operation{{Name: "a", Weight: 1, Run: noop}, {Name: "b", Weight: -1, Run: noop}}},
+		{"no weight", []soak.Operation{{Name: "a", Weight: 0, Run: noop}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := soak.Run(context.Background(), nil, soak.Config{Mix: tt.mix}); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
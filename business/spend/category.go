@@ -0,0 +1,139 @@
+package spend
+
+import (
+	"strconv"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+const (
+	Category_ACCOMMODATION = "accommodation"
+	Category_ADVERTISING   = "advertising"
+	Category_CASH          = "cash"
+	Category_ENTERTAINMENT = "entertainment"
+	Category_FUEL          = "fuel"
+	Category_GROCERIES     = "groceries"
+	Category_GENERAL       = "general"
+	Category_RESTAURANTS   = "restaurants"
+	Category_SERVICES      = "services"
+	Category_SHOPPING      = "shopping"
+	Category_SOFTWARE      = "software"
+	Category_TRANSPORT     = "transport"
+	Category_TRAVEL        = "travel"
+	Category_UTILITIES     = "utilities"
+)
+
+// mccRange is an inclusive range of merchant category codes of a spending category
+type mccRange struct {
+	from, to int
+	category string
+}
+
+// the spending categories of the merchant category codes, in the order they are matched
+var mccRanges = []mccRange{
+	{3000, 3299, Category_TRAVEL},
+	{3351, 3500, Category_TRANSPORT},
+	{3501, 3999, Category_ACCOMMODATION},
+	{4111, 4131, Category_TRANSPORT},
+	{4411, 4411, Category_TRAVEL},
+	{4457, 4457, Category_TRANSPORT},
+	{4468, 4468, Category_TRANSPORT},
+	{4511, 4511, Category_TRAVEL},
+	{4582, 4582, Category_TRAVEL},
+	{4722, 4723, Category_TRAVEL},
+	{4784, 4789, Category_TRANSPORT},
+	{4812, 4821, Category_UTILITIES},
+	{4899, 4900, Category_UTILITIES},
+	{5411, 5499, Category_GROCERIES},
+	{5541, 5542, Category_FUEL},
+	{5734, 5734, Category_SOFTWARE},
+	{5811, 5814, Category_RESTAURANTS},
+	{5815, 5818, Category_SOFTWARE},
+	{5000, 5999, Category_SHOPPING},
+	{6010, 6011, Category_CASH},
+	{7011, 7012, Category_ACCOMMODATION},
+	{7311, 7311, Category_ADVERTISING},
+	{7372, 7372, Category_SOFTWARE},
+	{7512, 7523, Category_TRANSPORT},
+	{7832, 7841, Category_ENTERTAINMENT},
+	{7911, 7999, Category_ENTERTAINMENT},
+	{7000, 8999, Category_SERVICES},
+}
+
+// CategoryOf returns the spending category of a merchant category code, Category_GENERAL when unknown.
+func CategoryOf(mcc string) string {
+	code, err := strconv.Atoi(mcc)
+	if err != nil {
+		return Category_GENERAL
+	}
+	for _, r := range mccRanges {
+		if code >= r.from && code <= r.to {
+			return r.category
+		}
+	}
+	return Category_GENERAL
+}
+
+// GLMapping maps card transactions to the general ledger codes of your chart of accounts.
+type GLMapping struct {
+	// GL codes by merchant category code, preferred over Categories
+	MCCs map[string]string
+	// GL codes by spending category, see CategoryOf
+	Categories map[string]string
+	// the GL code of the transactions matching neither
+	Default string
+}
+
+// GLCode returns the GL code of a transaction, from its merchant category code, then its spending category,
+// then the default.
+func (m *GLMapping) GLCode(tx *business.TransactionResp) string {
+	mcc := ""
+	if tx.Merchant != nil {
+		mcc = tx.Merchant.CategoryCode
+	}
+	if code, ok := m.MCCs[mcc]; ok && mcc != "" {
+		return code
+	}
+	if code, ok := m.Categories[CategoryOf(mcc)]; ok {
+		return code
+	}
+	return m.Default
+}
+
+// GLTotal is the spend booked to a GL code in a currency.
+type GLTotal struct {
+	GLCode   string
+	Currency string
+	// the spend, positive, net of refunds
+	Amount float64
+}
+
+// ByGLCode totals the card spend of the transactions per GL code and currency, netting refunds against payments.
+// Transactions which are not completed card payments or refunds are skipped.
+func (m *GLMapping) ByGLCode(txs []*business.TransactionResp) []*GLTotal {
+	var totals []*GLTotal
+	index := map[[2]string]*GLTotal{}
+	for _, tx := range txs {
+		if !isCardSpend(tx) {
+			continue
+		}
+		code := m.GLCode(tx)
+		for _, leg := range tx.Legs {
+			key := [2]string{code, leg.Currency}
+			t, ok := index[key]
+			if !ok {
+				t = &GLTotal{GLCode: code, Currency: leg.Currency}
+				index[key] = t
+				totals = append(totals, t)
+			}
+			t.Amount -= leg.Amount
+		}
+	}
+	return totals
+}
+
+// isCardSpend reports whether the transaction is a completed card payment or refund
+func isCardSpend(tx *business.TransactionResp) bool {
+	return tx.State == business.PaymentState_COMPLETE &&
+		(tx.Type == business.PaymentType_CARD_PAYMENT || tx.Type == business.PaymentType_CARD_REFUND)
+}
@@ -0,0 +1,74 @@
+package spend_test
+
+import (
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/spend"
+)
+
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		mcc  string
+		want string
+	}{
+		{"3005", spend.Category_TRAVEL},
+		{"4121", spend.Category_TRANSPORT},
+		{"5411", spend.Category_GROCERIES},
+		// a narrower range wins over the range of the shops around it
+		{"5734", spend.Category_SOFTWARE},
+		{"5999", spend.Category_SHOPPING},
+		{"5812", spend.Category_RESTAURANTS},
+		{"7372", spend.Category_SOFTWARE},
+		{"8111", spend.Category_SERVICES},
+		{"9402", spend.Category_GENERAL},
+		{"", spend.Category_GENERAL},
+		{"abc", spend.Category_GENERAL},
+	}
+	for _, tt := range tests {
+		if got := spend.CategoryOf(tt.mcc); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.mcc, got, tt.want)
+		}
+	}
+}
+
+func TestGLMappingByGLCode(t *testing.T) {
+	m := &spend.GLMapping{
+		MCCs:       map[string]string{"5734": "6200"},
+		Categories: map[string]string{spend.Category_SOFTWARE: "6210", spend.Category_RESTAURANTS: "6300"},
+		Default:    "6999",
+	}
+	card := func(typ business.PaymentType, state business.PaymentState, mcc string, amount float64, currency string) *business.TransactionResp {
+		return &business.TransactionResp{Type: typ, State: state, Merchant: &business.TransactionMerchant{CategoryCode: mcc},
+			Legs: []business.TransactionLeg{{Amount: amount, Currency: currency}}}
+	}
+	txs := []*business.TransactionResp{
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_COMPLETE, "5734", -50, "GBP"),
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_COMPLETE, "7372", -20, "GBP"),
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_COMPLETE, "5812", -30, "GBP"),
+		card(business.PaymentType_CARD_REFUND, business.PaymentState_COMPLETE, "5812", 10, "GBP"),
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_COMPLETE, "5812", -15, "EUR"),
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_COMPLETE, "1520", -5, "GBP"),
+		// not spent
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_PENDING, "5812", -99, "GBP"),
+		card(business.PaymentType_CARD_PAYMENT, business.PaymentState_DECLINE, "5812", -99, "GBP"),
+		{Type: business.PaymentType_TRANSFER, State: business.PaymentState_COMPLETE, Legs: []business.TransactionLeg{{Amount: -99, Currency: "GBP"}}},
+	}
+
+	want := []spend.GLTotal{
+		{GLCode: "6200", Currency: "GBP", Amount: 50},
+		{GLCode: "6210", Currency: "GBP", Amount: 20},
+		{GLCode: "6300", Currency: "GBP", Amount: 20},
+		{GLCode: "6300", Currency: "EUR", Amount: 15},
+		{GLCode: "6999", Currency: "GBP", Amount: 5},
+	}
+	got := m.ByGLCode(txs)
+	if len(got) != len(want) {
+		t.Fatalf("got %d totals, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("total %d: got %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package spend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+type Period string
+
+const (
+	Period_DAY     Period = "day"
+	Period_WEEK    Period = "week"
+	Period_MONTH   Period = "month"
+	Period_QUARTER Period = "quarter"
+	Period_YEAR    Period = "year"
+)
+
+// Start returns the start of the period containing t, in the location of t. Weeks start on Monday.
+func (p Period) Start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	switch p {
+	case Period_WEEK:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Period_MONTH:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case Period_QUARTER:
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, t.Location())
+	case Period_YEAR:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+// limit returns the limit of the card for the period, nil when none is set.
+func (p Period) limit(limits *business.CardSpendingLimits) *business.CardSpendingLimit {
+	if limits == nil {
+		return nil
+	}
+	switch p {
+	case Period_DAY:
+		return limits.Day
+	case Period_WEEK:
+		return limits.Week
+	case Period_MONTH:
+		return limits.Month
+	case Period_QUARTER:
+		return limits.Quarter
+	case Period_YEAR:
+		return limits.Year
+	}
+	return nil
+}
+
+// CardUsage is the spend of a card over the current period of one of its limits.
+type CardUsage struct {
+	Card *business.CardResp
+	// the start of the period
+	From time.Time
+	// the limit of the period, nil when the card has none
+	Limit *business.CardSpendingLimit
+	// the spend in the currency of the limit, or of the card's transactions without a limit, net of refunds.
+	// Pending payments count, as they count against the limit
+	Spent float64
+	// the spend in other currencies, which cannot be compared to the limit
+	Unconverted map[string]float64
+}
+
+// Remaining returns what can still be spent in the period, zero once the limit is reached, -1 without a limit.
+func (u *CardUsage) Remaining() float64 {
+	if u.Limit == nil {
+		return -1
+	}
+	if u.Spent >= u.Limit.Amount {
+		return 0
+	}
+	return u.Limit.Amount - u.Spent
+}
+
+// Exceeded reports whether the spend reached the limit.
+func (u *CardUsage) Exceeded() bool {
+	return u.Limit != nil && u.Spent >= u.Limit.Amount
+}
+
+// ComputeUsage returns the usage of every card over the period containing now. Transactions are matched to
+// cards by card ID, or by the last four digits of their masked card number when they carry no ID.
+func ComputeUsage(cards []*business.CardResp, txs []*business.TransactionResp, period Period, now time.Time) []*CardUsage {
+	from := period.Start(now)
+	usages := make([]*CardUsage, 0, len(cards))
+	byId, byDigits := map[string]*CardUsage{}, map[string]*CardUsage{}
+	for _, c := range cards {
+		u := &CardUsage{Card: c, From: from, Limit: period.limit(c.SpendingLimits), Unconverted: map[string]float64{}}
+		usages = append(usages, u)
+		byId[c.Id], byDigits[c.LastDigits] = u, u
+	}
+
+	for _, tx := range txs {
+		if tx.CreatedAt.Before(from) || tx.CreatedAt.After(now) || !countsAgainstLimit(tx) {
+			continue
+		}
+		u := cardUsage(tx.Card, byId, byDigits)
+		if u == nil {
+			continue
+		}
+		for _, leg := range tx.Legs {
+			u.add(&leg)
+		}
+	}
+
+	return usages
+}
+
+// cardUsage returns the usage of the card of a transaction, nil when it is none of the cards
+func cardUsage(card *business.TransactionCard, byId, byDigits map[string]*CardUsage) *CardUsage {
+	if card == nil {
+		return nil
+	}
+	if card.Id != "" {
+		return byId[card.Id]
+	}
+	if len(card.CardNumber) < 4 {
+		return nil
+	}
+	return byDigits[card.CardNumber[len(card.CardNumber)-4:]]
+}
+
+// add counts the leg, in the currency of the limit when either of its amounts is in it
+func (u *CardUsage) add(leg *business.TransactionLeg) {
+	currency := ""
+	if u.Limit != nil {
+		currency = u.Limit.Currency
+	}
+
+	switch {
+	case currency == "" || strings.EqualFold(leg.Currency, currency):
+		u.Spent -= leg.Amount
+	case leg.BillAmount != nil && strings.EqualFold(leg.BillCurrency, currency):
+		// the bill amount is unsigned, the sign of the leg tells payments from refunds
+		if leg.Amount < 0 {
+			u.Spent += *leg.BillAmount
+		} else {
+			u.Spent -= *leg.BillAmount
+		}
+	default:
+		u.Unconverted[leg.Currency] -= leg.Amount
+	}
+}
+
+// countsAgainstLimit reports whether the transaction is a pending or completed card payment or refund
+func countsAgainstLimit(tx *business.TransactionResp) bool {
+	if tx.State != business.PaymentState_PENDING && tx.State != business.PaymentState_COMPLETE {
+		return false
+	}
+	return tx.Type == business.PaymentType_CARD_PAYMENT || tx.Type == business.PaymentType_CARD_REFUND
+}
+
+// Usage lists the cards and the card transactions of the period containing now, and returns the usage of
+// every card, see ComputeUsage.
+func Usage(ctx context.Context, client *business.Client, period Period, now time.Time) ([]*CardUsage, error) {
+	cardService, err := client.Card()
+	if err != nil {
+		return nil, err
+	}
+	cards, err := cardService.ListAll(ctx).All()
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := client.Payment()
+	if err != nil {
+		return nil, err
+	}
+	var txs []*business.TransactionResp
+	for _, t := range []business.PaymentType{business.PaymentType_CARD_PAYMENT, business.PaymentType_CARD_REFUND} {
+		page, err := payments.ListAll(ctx, &business.TransactionReq{
+			From: period.Start(now).Format(time.RFC3339Nano),
+			To:   now.Format(time.RFC3339Nano),
+			Type: t,
+		}).All()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, page...)
+	}
+
+	return ComputeUsage(cards, txs, period, now), nil
+}
@@ -0,0 +1,77 @@
+package business
+
+import (
+	"context"
+	"fmt"
+)
+
+// RateSpread compares a rate quoted by Revolut with a reference mid-market rate, e.g. for FX cost monitoring.
+// The spreads are in basis points of the mid rate, positive when the quote gives less than the mid rate.
+type RateSpread struct {
+	From Currency `json:"from"`
+	To   Currency `json:"to"`
+	// the rate quoted, in To per From
+	Quoted float64 `json:"quoted"`
+	// the rate once the fee is paid, the amount received for the amount debited
+	Effective float64 `json:"effective"`
+	// the reference mid-market rate
+	Mid float64 `json:"mid"`
+	// the spread of the quoted rate alone
+	RateBps float64 `json:"rate_bps"`
+	// the spread of the effective rate, the fee included
+	TotalBps float64 `json:"total_bps"`
+}
+
+// Spread compares the quote with mid, the mid-market rate of the pair in To per From. The fee is charged in the
+// source currency or in the target one; a fee in any other currency cannot be compared and fails.
+func (r *ExchangeRateResp) Spread(mid float64) (*RateSpread, error) {
+	if mid <= 0 {
+		return nil, invalid("mid", "must be positive")
+	}
+
+	effective := r.Rate
+	if r.From.Amount > 0 {
+		sent, received := r.From.Amount, r.To.Amount
+		switch {
+		case r.Fee.Amount == 0:
+		case r.Fee.Currency == r.From.Currency:
+			sent += r.Fee.Amount
+		case r.Fee.Currency == r.To.Currency:
+			received -= r.Fee.Amount
+		default:
+			return nil, fmt.Errorf("exchange: the fee in %s of a %s/%s rate cannot be compared", r.Fee.Currency, r.From.Currency, r.To.Currency)
+		}
+		effective = received / sent
+	}
+
+	return &RateSpread{
+		From:      Currency(r.From.Currency),
+		To:        Currency(r.To.Currency),
+		Quoted:    r.Rate,
+		Effective: effective,
+		Mid:       mid,
+		RateBps:   spreadBps(r.Rate, mid),
+		TotalBps:  spreadBps(effective, mid),
+	}, nil
+}
+
+func spreadBps(rate, mid float64) float64 {
+	return (mid - rate) / mid * 10000
+}
+
+// CompareRate fetches the rate of the exchange and compares it with mid, the mid-market rate of the pair in To
+// per From, see ExchangeRateResp.Spread. The amount matters: the fee of small exchanges weighs more.
+func (b *Client) CompareRate(ctx context.Context, exchangeRateReq *ExchangeRateReq, mid float64) (*RateSpread, error) {
+	if mid <= 0 {
+		return nil, invalid("mid", "must be positive")
+	}
+	e, err := b.Exchange()
+	if err != nil {
+		return nil, err
+	}
+	rate, err := e.Rate(ctx, exchangeRateReq)
+	if err != nil {
+		return nil, err
+	}
+	return rate.Spread(mid)
+}
@@ -0,0 +1,92 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type StatementFormat string
+
+const (
+	StatementFormat_PDF StatementFormat = "pdf"
+	StatementFormat_CSV StatementFormat = "csv"
+)
+
+// mime type of every statement format, sent as Accept
+var statementMimeTypes = map[StatementFormat]string{
+	StatementFormat_PDF: "application/pdf",
+	StatementFormat_CSV: "text/csv",
+}
+
+type StatementReq struct {
+	// the ID of the account
+	AccountId string
+	// the first day of the statement, YYYY-MM-DD
+	From string
+	// an optional last day of the statement, YYYY-MM-DD. Default is today
+	To string
+	// the format of the statement, pdf or csv
+	Format StatementFormat
+}
+
+// Validate checks the account, start and format of a statement request.
+func (r *StatementReq) Validate() error {
+	if _, ok := statementMimeTypes[r.Format]; !ok {
+		return invalid("format", "must be pdf or csv")
+	}
+	return firstErr(
+		required("account_id", r.AccountId),
+		required("from", r.From),
+	)
+}
+
+func (r *StatementReq) params() url.Values {
+	params := url.Values{}
+	params.Add("from", r.From)
+	if r.To != "" {
+		params.Add("to", r.To)
+	}
+	params.Add("format", string(r.Format))
+
+	return params
+}
+
+// Statement: This endpoint retrieves the statement of an account for a period, as a PDF or CSV document.
+// The document is copied to w as it is received rather than held in memory, and the number of bytes written
+// is returned. Statements are not available on every plan, the API error is returned then.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#accounts-get-account-statement
+func (a *AccountService) Statement(ctx context.Context, statementReq *StatementReq, w io.Writer) (int64, error) {
+	if err := statementReq.Validate(); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "account.statement",
+		Method:      http.MethodGet,
+		Url:         endpoint(statementReq.params(), "accounts", statementReq.AccountId, "statement"),
+		AccessToken: a.accessToken,
+		Sandbox:     a.sandbox,
+		Options:     a.options,
+		Context:     ctx,
+		Header:      http.Header{"Accept": {statementMimeTypes[statementReq.Format]}},
+		Stream: func(body io.Reader) error {
+			var err error
+			n, err = io.Copy(w, body)
+			return err
+		},
+	})
+	if err != nil {
+		return n, err
+	}
+	if statusCode != http.StatusOK {
+		return n, errors.New(string(resp))
+	}
+
+	return n, nil
+}
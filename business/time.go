@@ -0,0 +1,62 @@
+package business
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timeLayouts are the timestamp formats the API was seen to return, tried in order. Timestamps without a zone
+// are in UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Time is a time.Time decoding every timestamp variant returned by the API: RFC 3339 with any number of
+// fractional digits, offsets without a colon, a space instead of the T, no zone at all, plain dates and
+// Unix timestamps in milliseconds. It encodes as RFC 3339.
+type Time struct {
+	time.Time
+}
+
+// ParseTime parses a timestamp in any of the variants of Time.
+func ParseTime(s string) (Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Time{t}, nil
+		}
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Time{time.UnixMilli(ms).UTC()}, nil
+	}
+	return Time{}, fmt.Errorf("time: cannot parse %q as a timestamp", s)
+}
+
+func (t *Time) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	if s == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,363 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+// Token is an access token together with the refresh token used to renew it.
+type Token struct {
+	// the access token
+	AccessToken string `json:"access_token"`
+	// the token used to request a new access token
+	RefreshToken string `json:"refresh_token"`
+	// the instant when the access token expires
+	Expiry time.Time `json:"expiry"`
+	// the instant when the user's consent expires, zero when unknown
+	ConsentExpiry time.Time `json:"consent_expiry,omitempty"`
+}
+
+// valid reports whether the access token can still be used for at least leeway.
+func (t *Token) valid(leeway time.Duration) bool {
+	return t != nil && t.AccessToken != "" && time.Now().Add(leeway).Before(t.Expiry)
+}
+
+// TokenStore persists the tokens of a client. Implementations must be safe for concurrent use.
+// Deployments running several instances can share refreshed tokens through a common backend such as Redis or Vault.
+// Token.MarshalJSON redacts the access and refresh tokens, so a store persisting tokens as JSON must marshal a
+// (*RawToken)(token), like FileTokenStore, or it stores "***" and the next refresh fails. Unmarshalling a Token
+// is not redacted.
+type TokenStore interface {
+	// Get returns the stored token, or nil without error when the store is empty
+	Get(ctx context.Context) (*Token, error)
+	Set(ctx context.Context, token *Token) error
+}
+
+// TokenLocker can optionally be implemented by a TokenStore to serialise refreshes across instances,
+// e.g. with a distributed lock, so only one of them spends the refresh token.
+// The context of Lock bounds the wait for the lock; the one of Unlock is never cancelled, so the lock is released
+// even when the caller gave up.
+type TokenLocker interface {
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+// MemoryTokenStore keeps the token in memory.
+type MemoryTokenStore struct {
+	mu    sync.RWMutex
+	token *Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Get(ctx context.Context) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.token == nil {
+		return nil, nil
+	}
+	t := *s.token
+	return &t, nil
+}
+
+func (s *MemoryTokenStore) Set(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := *token
+	s.token = &t
+	return nil
+}
+
+// FileTokenStore keeps the token as JSON in a file readable only by its owner.
+// Writes go through a temporary file and a rename so readers never see a partial token.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) Get(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Token{}
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *FileTokenStore) Set(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal((*RawToken)(token))
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path)
+}
+
+const (
+	// tokenLeeway is how long before its expiry an access token is refreshed
+	tokenLeeway = time.Minute
+	// consentWarning is how long before the consent expires ConsentExpiring events are published
+	consentWarning = 7 * 24 * time.Hour
+	// refreshTimeout bounds a refresh, which runs detached from the context of the caller
+	refreshTimeout = 30 * time.Second
+)
+
+// ConsentLifetime is how long the consent of a user lasts for the scopes that expire, after which the refresh
+// token is rejected and the user has to authorise the application again.
+const ConsentLifetime = 90 * 24 * time.Hour
+
+// Reauthorisation tells an application that the user has to consent again.
+type Reauthorisation struct {
+	// the instant the consent expires or expired, zero when unknown
+	ExpiresAt time.Time
+	// the refresh error when Revolut rejected the refresh token, nil while the consent is still valid
+	Err error
+}
+
+// TokenManager hands out valid access tokens, refreshing them through the OAuth service when they expire.
+// It is safe for concurrent use.
+type TokenManager struct {
+	mu           sync.Mutex
+	oa           *OAuthService
+	store        TokenStore
+	refreshToken string
+	events       *EventBus
+	// called when the user has to consent again, see WithOnReauthorisationRequired
+	onReauth func(Reauthorisation)
+	// the consent expiry onReauth was last called for, it is called once per expiry
+	notified time.Time
+}
+
+// NewTokenManager returns a manager backed by the store. The refresh token is used
+// until the store holds one of its own.
+func NewTokenManager(oa *OAuthService, store TokenStore, refreshToken string) *TokenManager {
+	return &TokenManager{
+		oa:           oa,
+		store:        store,
+		refreshToken: refreshToken,
+	}
+}
+
+// AccessToken returns a valid access token, refreshing it first when needed. The context bounds the store and
+// its lock; a refresh, shared by every caller waiting for it, is only cancelled by its own timeout.
+func (m *TokenManager) AccessToken(ctx context.Context) (string, error) {
+	t, err := m.store.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+	if t.valid(tokenLeeway) {
+		return t.AccessToken, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.store.(TokenLocker); ok {
+		if err := l.Lock(ctx); err != nil {
+			return "", err
+		}
+		defer l.Unlock(detached{ctx})
+	}
+
+	// another goroutine or instance may have refreshed while we waited for the lock
+	t, err = m.store.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+	if t.valid(tokenLeeway) {
+		return t.AccessToken, nil
+	}
+
+	t, err = m.refresh(ctx, t)
+	if err != nil {
+		return "", err
+	}
+
+	return t.AccessToken, nil
+}
+
+func (m *TokenManager) refresh(ctx context.Context, current *Token) (*Token, error) {
+	refreshToken := m.refreshToken
+	var consentExpiry time.Time
+	if current != nil {
+		if current.RefreshToken != "" {
+			refreshToken = current.RefreshToken
+		}
+		consentExpiry = current.ConsentExpiry
+	}
+
+	// refreshes are shared by every caller of AccessToken, so none of their contexts may cancel one, and a
+	// rotated refresh token must be stored even when the caller gave up
+	ctx, cancel := context.WithTimeout(detached{ctx}, refreshTimeout)
+	defer cancel()
+
+	issuedAt := time.Now()
+	resp, err := m.oa.RefreshAccessToken(ctx, refreshToken)
+	if err != nil {
+		m.events.Publish(TokenRefreshFailed{Err: err})
+		if isConsentRevoked(err) {
+			m.events.Publish(ConsentRevoked{Err: err})
+			m.reauthorisationRequired(Reauthorisation{ExpiresAt: consentExpiry, Err: err})
+		}
+		return nil, err
+	}
+
+	// Revolut only returns a refresh token when it rotates it
+	rotated := resp.RefreshToken != "" && resp.RefreshToken != refreshToken
+	if resp.RefreshToken != "" {
+		refreshToken = resp.RefreshToken
+	}
+
+	t := &Token{
+		AccessToken:   resp.AccessToken,
+		RefreshToken:  refreshToken,
+		Expiry:        issuedAt.Add(time.Duration(resp.ExpiresIn) * time.Second),
+		ConsentExpiry: consentExpiry,
+	}
+	if err := m.store.Set(ctx, t); err != nil {
+		return nil, err
+	}
+
+	m.events.Publish(TokenRefreshed{ExpiresAt: t.Expiry, RefreshTokenRotated: rotated})
+	if !consentExpiry.IsZero() && time.Until(consentExpiry) < consentWarning {
+		m.events.Publish(ConsentExpiring{ExpiresAt: consentExpiry})
+		if !m.notified.Equal(consentExpiry) {
+			m.notified = consentExpiry
+			m.reauthorisationRequired(Reauthorisation{ExpiresAt: consentExpiry})
+		}
+	}
+
+	return t, nil
+}
+
+// ConsentExpiry returns the instant the user's consent expires, zero when unknown.
+func (m *TokenManager) ConsentExpiry(ctx context.Context) (time.Time, error) {
+	t, err := m.store.Get(ctx)
+	if err != nil || t == nil {
+		return time.Time{}, err
+	}
+	return t.ConsentExpiry, nil
+}
+
+func (m *TokenManager) reauthorisationRequired(r Reauthorisation) {
+	if m.onReauth != nil {
+		m.onReauth(r)
+	}
+}
+
+// Revoke revokes the refresh token and empties the store, so the manager hands out no more access tokens.
+// The user has to consent again before the application can call the API on their behalf.
+func (m *TokenManager) Revoke(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.store.(TokenLocker); ok {
+		if err := l.Lock(ctx); err != nil {
+			return err
+		}
+		defer l.Unlock(detached{ctx})
+	}
+
+	t, err := m.store.Get(ctx)
+	if err != nil {
+		return err
+	}
+	refreshToken := m.refreshToken
+	if t != nil && t.RefreshToken != "" {
+		refreshToken = t.RefreshToken
+	}
+
+	if err := m.oa.RevokeToken(ctx, refreshToken, TokenTypeHint_REFRESH_TOKEN); err != nil {
+		return err
+	}
+
+	m.refreshToken = ""
+	if err := m.store.Set(ctx, &Token{}); err != nil {
+		return err
+	}
+
+	m.events.Publish(ConsentRevoked{})
+	return nil
+}
+
+// isConsentRevoked reports whether a refresh failed because the refresh token is no longer accepted.
+func isConsentRevoked(err error) bool {
+	var apiErr *request.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusUnauthorized ||
+		(apiErr.StatusCode == http.StatusBadRequest && strings.Contains(apiErr.Body, "invalid_grant"))
+}
+
+// seedConsentExpiry records the consent expiry in an empty store, so it is carried over on every refresh.
+func seedConsentExpiry(ctx context.Context, store TokenStore, consentExpiry time.Time) error {
+	t, err := store.Get(ctx)
+	if err != nil || t != nil {
+		return err
+	}
+
+	return store.Set(ctx, &Token{ConsentExpiry: consentExpiry})
+}
+
+// detached keeps the values of a context, such as its trace, without its deadline and cancellation
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detached) Done() <-chan struct{} { return nil }
+
+func (detached) Err() error { return nil }
@@ -0,0 +1,142 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/quiver-london/go-revolut/v2/business/request"
+)
+
+type TransferService struct {
+	accessToken string
+	sandbox     bool
+	options     *request.Options
+}
+
+type TransferReq struct {
+	// a unique value used to handle duplicates submitted as a
+	// result of lost connection or another client error (40 characters max)
+	RequestId string `json:"request_id"`
+	// the ID of a source account
+	SourceAccountId string `json:"source_account_id"`
+	// the ID of a target account
+	TargetAccountId string `json:"target_account_id"`
+	// the transaction amount
+	Amount float64 `json:"amount"`
+	// the transaction currency, both source and target accounts should be in this currency
+	Currency string `json:"currency"`
+	// an optional textual reference shown on the transaction
+	Reference string `json:"reference,omitempty"`
+}
+
+type TransferState string
+
+const (
+	TransferState_PENDING  TransferState = "pending"
+	TransferState_COMPLETE TransferState = "completed"
+	TransferState_DECLINE  TransferState = "declined"
+	TransferState_FAILED   TransferState = "failed"
+	TransferState_REVERTED TransferState = "reverted"
+)
+
+type TransferResp struct {
+	// the ID of the created transaction
+	Id string `json:"id"`
+	// the request ID the transfer was made with, set from the request as the API does not return it
+	RequestId string `json:"request_id,omitempty"`
+	// the transction state: pending, completed, declined, failed or reverted
+	State TransferState `json:"state"`
+	// the instant when the transaction was created
+	CreatedAt Time `json:"created_at"`
+	// the instant when the transaction was completed, nil unless completed
+	CompletedAt *Time `json:"completed_at,omitempty"`
+}
+
+type TransferReason struct {
+	// the country the reason applies to
+	Country string `json:"country"`
+	// the currency the reason applies to
+	Currency string `json:"currency"`
+	// the code to send as transfer_reason_code
+	Code string `json:"code"`
+	// the description of the reason
+	Description string `json:"description"`
+}
+
+// Create: This endpoint processes transfers between accounts of the business with the same currency. Without a
+// RequestId, one is generated and returned on the response, transferReq is not modified: set RequestId before the
+// call to retry it safely after an error.
+// doc: https://revolut-engineering.github.io/api-docs/business-api/#transfers-create-transfer
+func (t *TransferService) Create(ctx context.Context, transferReq *TransferReq) (*TransferResp, error) {
+	// a missing request ID is generated on a copy, the request of the caller is left untouched
+	generated := transferReq.RequestId == ""
+	if generated {
+		id, err := t.options.NewId()
+		if err != nil {
+			return nil, err
+		}
+		req := *transferReq
+		req.RequestId = id
+		transferReq = &req
+	}
+	if err := transferReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := request.New(request.Config{
+		Operation:      "transfer.create",
+		Method:         http.MethodPost,
+		Url:            endpoint(nil, "transfer"),
+		AccessToken:    t.accessToken,
+		Sandbox:        t.sandbox,
+		Options:        t.options,
+		Context:        ctx,
+		Body:           transferReq,
+		ContentType:    request.ContentType_APPLICATION_JSON,
+		IdempotencyKey: transferReq.RequestId,
+		GeneratedKey:   generated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := &TransferResp{}
+	if err := json.Unmarshal(resp, r); err != nil {
+		return nil, err
+	}
+	r.RequestId = transferReq.RequestId
+
+	return r, nil
+}
+
+// Reasons: Get the list of transfer reasons, some countries and currencies require one on payments.
+// doc: https://developer.revolut.com/docs/business/get-transfer-reasons
+func (t *TransferService) Reasons(ctx context.Context) ([]*TransferReason, error) {
+	resp, statusCode, err := request.New(request.Config{
+		Operation:   "transfer.reasons",
+		Method:      http.MethodGet,
+		Url:         endpoint(nil, "transfer-reasons"),
+		AccessToken: t.accessToken,
+		Sandbox:     t.sandbox,
+		Options:     t.options,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, errors.New(string(resp))
+	}
+
+	r := []*TransferReason{}
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,225 @@
+package treasury
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// VolumeSpike describes an exchange that would push the volume of a pair beyond the allowed multiple of its trailing average.
+type VolumeSpike struct {
+	// the exchanged pair, e.g. GBP/USD
+	Pair string
+	// the currency the volume is measured in, the currency of the side carrying the amount
+	Currency string
+	// the volume of the current period including the checked exchange
+	Volume float64
+	// the average volume of the trailing periods
+	TrailingAverage float64
+	// Volume divided by TrailingAverage, 0 without trailing volume
+	Multiple float64
+	// the largest volume allowed in the period, the multiple of the average or the floor
+	Limit float64
+}
+
+// VolumeSpikeError is returned when an exchange is blocked by a VolumeGuard.
+type VolumeSpikeError struct {
+	*VolumeSpike
+}
+
+func (e *VolumeSpikeError) Error() string {
+	if e.TrailingAverage == 0 {
+		return fmt.Sprintf("treasury: %s volume of %0.2f %s exceeds the floor of %0.2f without trailing volume",
+			e.Pair, e.Volume, e.Currency, e.Limit)
+	}
+	return fmt.Sprintf("treasury: %s volume of %0.2f %s is %0.1fx the trailing average of %0.2f",
+		e.Pair, e.Volume, e.Currency, e.Multiple, e.TrailingAverage)
+}
+
+// Approver decides whether an exchange exceeding the guard's limit may go ahead anyway.
+// The context carries the acting user requesting the exchange, see request.ActingUserFromContext.
+type Approver interface {
+	Approve(ctx context.Context, exchangeReq *business.ExchangeReq, spike *VolumeSpike) (bool, error)
+}
+
+type volumeKey struct {
+	pair     string
+	currency string
+}
+
+// VolumeGuard is a fat-finger protection that tracks exchanged volume per pair and period and holds
+// exchanges that exceed MaxMultiple times the average volume of the trailing periods, or MinVolume when larger.
+// Without a MinVolume, pairs without any trailing volume have no baseline and are not limited: the first exchange
+// of a pair always goes through, set MinVolume to guard it too. It is safe for concurrent use: Check reserves the
+// volume of the exchange it allows, so concurrent exchanges cannot all pass the same limit.
+type VolumeGuard struct {
+	// the length of one period, e.g. 24 hours
+	Period time.Duration
+	// the number of past periods the trailing average is computed over
+	TrailingPeriods int
+	// the multiple of the trailing average above which an exchange is held
+	MaxMultiple float64
+	// the volume of a period allowed whatever the trailing average, also the limit of pairs without trailing
+	// volume; none when zero
+	MinVolume float64
+	// an optional approver consulted for held exchanges, without one they are blocked
+	Approver Approver
+
+	mu      sync.Mutex
+	volumes map[volumeKey]map[int64]float64
+}
+
+// Exchange checks the exchange against the guard and executes it. Its volume stays counted only when the exchange
+// is executed: an error or a declined exchange releases it.
+func (g *VolumeGuard) Exchange(ctx context.Context, e business.Exchanger, exchangeReq *business.ExchangeReq) (*business.ExchangeResp, error) {
+	period, err := g.reserve(ctx, exchangeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := e.Exchange(ctx, exchangeReq)
+	if err != nil || r.State == business.PaymentState_DECLINE || r.State == business.PaymentState_FAILED {
+		g.release(exchangeReq, period)
+	}
+	return r, err
+}
+
+// Check returns a *VolumeSpikeError when the exchange exceeds the limit and is not approved. An allowed exchange
+// has its volume reserved in the current period: call Release when it is not executed after all.
+func (g *VolumeGuard) Check(ctx context.Context, exchangeReq *business.ExchangeReq) error {
+	_, err := g.reserve(ctx, exchangeReq)
+	return err
+}
+
+// Release gives back the volume Check reserved for an exchange that was not executed, e.g. failed or declined.
+func (g *VolumeGuard) Release(exchangeReq *business.ExchangeReq) {
+	g.mu.Lock()
+	period := g.period()
+	g.mu.Unlock()
+	g.release(exchangeReq, period)
+}
+
+// Record adds the volume of an exchange executed without Check, e.g. by another application, to the current period.
+func (g *VolumeGuard) Record(exchangeReq *business.ExchangeReq) {
+	key, amount := exchangeVolume(exchangeReq)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.add(key, g.period(), amount)
+}
+
+// reserve checks the exchange and counts its volume in the current period when it is allowed, returning the period
+func (g *VolumeGuard) reserve(ctx context.Context, exchangeReq *business.ExchangeReq) (int64, error) {
+	key, amount := exchangeVolume(exchangeReq)
+
+	g.mu.Lock()
+	period := g.period()
+	spike := g.spike(key, period, amount)
+	if spike == nil {
+		g.add(key, period, amount)
+	}
+	g.mu.Unlock()
+	if spike == nil {
+		return period, nil
+	}
+
+	if g.Approver != nil {
+		approved, err := g.Approver.Approve(ctx, exchangeReq, spike)
+		if err != nil {
+			return 0, err
+		}
+		if approved {
+			g.mu.Lock()
+			g.add(key, period, amount)
+			g.mu.Unlock()
+			return period, nil
+		}
+	}
+
+	return 0, &VolumeSpikeError{VolumeSpike: spike}
+}
+
+// spike returns the spike the amount would cause in the period, nil within the limit; the guard is locked
+func (g *VolumeGuard) spike(key volumeKey, period int64, amount float64) *VolumeSpike {
+	current := g.volumes[key][period] + amount
+	var trailing float64
+	for i := int64(1); i <= int64(g.TrailingPeriods); i++ {
+		trailing += g.volumes[key][period-i]
+	}
+
+	var average float64
+	if g.TrailingPeriods > 0 {
+		average = trailing / float64(g.TrailingPeriods)
+	}
+	limit := average * g.MaxMultiple
+	if g.MinVolume > limit {
+		limit = g.MinVolume
+	}
+	if (average == 0 && g.MinVolume <= 0) || current <= limit {
+		return nil
+	}
+
+	spike := &VolumeSpike{
+		Pair:            key.pair,
+		Currency:        key.currency,
+		Volume:          current,
+		TrailingAverage: average,
+		Limit:           limit,
+	}
+	if average > 0 {
+		spike.Multiple = current / average
+	}
+	return spike
+}
+
+// release subtracts the volume of the exchange from the period it was reserved in
+func (g *VolumeGuard) release(exchangeReq *business.ExchangeReq, period int64) {
+	key, amount := exchangeVolume(exchangeReq)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if v, ok := g.volumes[key][period]; ok {
+		if v -= amount; v < 0 {
+			v = 0
+		}
+		g.volumes[key][period] = v
+	}
+}
+
+// add counts the amount in the period and forgets the periods out of the trailing average; the guard is locked
+func (g *VolumeGuard) add(key volumeKey, period int64, amount float64) {
+	if g.volumes == nil {
+		g.volumes = map[volumeKey]map[int64]float64{}
+	}
+	if g.volumes[key] == nil {
+		g.volumes[key] = map[int64]float64{}
+	}
+	g.volumes[key][period] += amount
+
+	for p := range g.volumes[key] {
+		if p < period-int64(g.TrailingPeriods) {
+			delete(g.volumes[key], p)
+		}
+	}
+}
+
+func (g *VolumeGuard) period() int64 {
+	if g.Period <= 0 {
+		return 0
+	}
+	return time.Now().UnixNano() / int64(g.Period)
+}
+
+func exchangeVolume(exchangeReq *business.ExchangeReq) (volumeKey, float64) {
+	key := volumeKey{pair: string(exchangeReq.From.Currency + "/" + exchangeReq.To.Currency)}
+	if exchangeReq.From.Amount != 0 {
+		key.currency = string(exchangeReq.From.Currency)
+		return key, exchangeReq.From.Amount
+	}
+
+	key.currency = string(exchangeReq.To.Currency)
+	return key, exchangeReq.To.Amount
+}
@@ -0,0 +1,169 @@
+package treasury
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+type InterCompanyReq struct {
+	// the pool ID of the paying entity
+	From string
+	// the pool ID of the receiving entity
+	To string
+	// the ID of the paying entity's account to pay from
+	SourceAccountId string
+	// the ID of the counterparty representing the receiving entity, as held by the paying entity
+	CounterpartyId string
+	// the ID of the counterparty's account to pay to
+	CounterpartyAccountId string
+	// the ID of the receiving entity's own account the funds arrive on
+	TargetAccountId string
+	// the client provided ID of the payment (40 characters max)
+	RequestId string
+	// the transfer amount
+	Amount float64
+	// the transfer currency
+	Currency string
+	// an optional textual reference, also used to match the incoming leg
+	Reference string
+}
+
+// InterCompanyTransfer tracks both legs of a transfer between two connected entities.
+type InterCompanyTransfer struct {
+	Req *InterCompanyReq
+	// the payment made by the paying entity
+	Outgoing *business.TransactionResp
+	// the matching transaction of the receiving entity, nil until receipt is confirmed
+	Incoming *business.TransactionResp
+}
+
+// Confirmed reports whether the receiving entity has seen the funds arrive.
+func (t *InterCompanyTransfer) Confirmed() bool {
+	return t.Incoming != nil
+}
+
+// InterCompany pays from the entity req.From to the counterparty record of the entity req.To.
+func InterCompany(ctx context.Context, pool *business.ClientPool, req *InterCompanyReq) (*InterCompanyTransfer, error) {
+	from, err := pool.Get(req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := from.Payment()
+	if err != nil {
+		return nil, err
+	}
+	outgoing, err := payments.Create(ctx, &business.PaymentReq{
+		RequestId: req.RequestId,
+		AccountId: req.SourceAccountId,
+		Receiver: business.PaymentReceiver{
+			CounterpartyId: req.CounterpartyId,
+			AccountId:      req.CounterpartyAccountId,
+		},
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Reference: req.Reference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &InterCompanyTransfer{Req: req, Outgoing: outgoing}, nil
+}
+
+// ConfirmInterCompany looks for the incoming leg in the receiving entity's transactions since the payment was created
+// and records it on the transfer. It returns whether receipt is confirmed. Several transfers of the same amount and
+// reference are told apart with ConfirmInterCompanies.
+func ConfirmInterCompany(ctx context.Context, pool *business.ClientPool, t *InterCompanyTransfer) (bool, error) {
+	if err := ConfirmInterCompanies(ctx, pool, []*InterCompanyTransfer{t}); err != nil {
+		return false, err
+	}
+	return t.Confirmed(), nil
+}
+
+// ConfirmInterCompanies looks for the incoming legs of the transfers, oldest payment first, in every page of the
+// receiving entities' transactions since the payments were created. A transaction is matched to one transfer at
+// most, those already recorded on a confirmed transfer are not matched again.
+func ConfirmInterCompanies(ctx context.Context, pool *business.ClientPool, transfers []*InterCompanyTransfer) error {
+	matched := map[string]bool{}
+	var entities []string
+	pending := map[string][]*InterCompanyTransfer{}
+	for _, t := range transfers {
+		if t.Confirmed() {
+			matched[t.Incoming.Id] = true
+			continue
+		}
+		if pending[t.Req.To] == nil {
+			entities = append(entities, t.Req.To)
+		}
+		pending[t.Req.To] = append(pending[t.Req.To], t)
+	}
+
+	for _, entity := range entities {
+		if err := confirm(ctx, pool, entity, pending[entity], matched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// confirm matches the transfers received by an entity to its transactions, skipping and adding to the matched ones
+func confirm(ctx context.Context, pool *business.ClientPool, entity string, transfers []*InterCompanyTransfer, matched map[string]bool) error {
+	to, err := pool.Get(entity)
+	if err != nil {
+		return err
+	}
+	payments, err := to.Payment()
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].Outgoing.CreatedAt.Before(transfers[j].Outgoing.CreatedAt.Time)
+	})
+	// the incoming leg may be stamped slightly before the payment
+	since := func(t *InterCompanyTransfer) time.Time {
+		return t.Outgoing.CreatedAt.Add(-time.Minute)
+	}
+	txs, err := payments.ListAll(ctx, &business.TransactionReq{
+		From: since(transfers[0]).Format(time.RFC3339),
+	}).All()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].CreatedAt.Before(txs[j].CreatedAt.Time)
+	})
+
+	for _, t := range transfers {
+		for _, tx := range txs {
+			if matched[tx.Id] || tx.CreatedAt.Before(since(t)) || !t.receivedBy(tx) {
+				continue
+			}
+			t.Incoming = tx
+			matched[tx.Id] = true
+			break
+		}
+	}
+	return nil
+}
+
+// receivedBy reports whether the transaction is the completed incoming leg of the transfer
+func (t *InterCompanyTransfer) receivedBy(tx *business.TransactionResp) bool {
+	if tx.State != business.PaymentState_COMPLETE {
+		return false
+	}
+	if t.Req.Reference != "" && tx.Reference != t.Req.Reference {
+		return false
+	}
+	for _, leg := range tx.Legs {
+		if leg.AccountId == t.Req.TargetAccountId && leg.Currency == t.Req.Currency && math.Abs(leg.Amount-t.Req.Amount) < 0.005 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package treasury
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func TestConfirmInterCompany(t *testing.T) {
+	// more transactions than a page, all newer than the transfers, on an account of neither entity
+	var later []*business.TransactionResp
+	for i := 0; i < 1000; i++ {
+		later = append(later, &business.TransactionResp{
+			Id: fmt.Sprintf("later-%d", i), State: business.PaymentState_COMPLETE, CreatedAt: business.Time{Time: time.Now().Add(time.Hour + time.Duration(i)*time.Second)},
+			Legs: []business.TransactionLeg{{AccountId: "other", Amount: -1, Currency: "GBP"}},
+		})
+	}
+	// one server holds the accounts of both entities, paying the receiving account credits it
+	s := revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts: []*business.AccountResp{
+			{Id: "payer", Currency: "GBP", Balance: 1000},
+			{Id: "receiver", Currency: "GBP", Balance: 0},
+			{Id: "other", Currency: "GBP", Balance: 1000},
+		},
+		Counterparties: []*business.CounterpartyResp{{Id: "receiver-entity", Name: "Receiver Ltd"}},
+		Transactions:   later,
+	})
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := business.NewClientPool()
+	pool.Add("payer", c)
+	pool.Add("receiver", c)
+
+	ctx := context.Background()
+	pay := func(requestId string) *InterCompanyTransfer {
+		transfer, err := InterCompany(ctx, pool, &InterCompanyReq{
+			From: "payer", To: "receiver",
+			SourceAccountId: "payer", CounterpartyId: "receiver-entity", CounterpartyAccountId: "receiver", TargetAccountId: "receiver",
+			RequestId: requestId, Amount: 50, Currency: "GBP", Reference: "Management fee",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return transfer
+	}
+
+	first := pay("fee-1")
+	if ok, err := ConfirmInterCompany(ctx, pool, first); err != nil || !ok {
+		t.Fatalf("got %v, %v, want the first transfer confirmed past the first page", ok, err)
+	}
+	if first.Incoming.Id != first.Outgoing.Id {
+		t.Errorf("got incoming %s, want %s", first.Incoming.Id, first.Outgoing.Id)
+	}
+
+	// a second transfer of the same amount and reference is not matched to the first one's transaction
+	second := pay("fee-2")
+	if err := ConfirmInterCompanies(ctx, pool, []*InterCompanyTransfer{first, second}); err != nil {
+		t.Fatal(err)
+	}
+	if !second.Confirmed() || second.Incoming.Id != second.Outgoing.Id {
+		t.Errorf("got the second transfer matched to %v, want %s", second.Incoming, second.Outgoing.Id)
+	}
+
+	// without a transaction left, a third transfer is not confirmed
+	third := &InterCompanyTransfer{Req: second.Req, Outgoing: second.Outgoing}
+	if err := ConfirmInterCompanies(ctx, pool, []*InterCompanyTransfer{first, second, third}); err != nil {
+		t.Fatal(err)
+	}
+	if third.Confirmed() {
+		t.Errorf("got the third transfer matched to %s, already matched", third.Incoming.Id)
+	}
+}
@@ -0,0 +1,153 @@
+package treasury
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// defaultLookback is how long before a period transactions completed in it may have been created
+const defaultLookback = 30 * 24 * time.Hour
+
+// Period is an accounting period [Start, End), such as a month or a quarter in the time zone of the books.
+type Period struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// the calendar months of the period, 0 for a custom one
+	months int
+}
+
+// Month returns the calendar month of the year in the location, e.g. Month(2024, time.March, london) from
+// midnight on 1 March to midnight on 1 April, London time. A nil location means UTC.
+func Month(year int, month time.Month, loc *time.Location) Period {
+	return months(year, month, 1, loc)
+}
+
+// Quarter returns the calendar quarter of the year in the location, 1 to 4, e.g. Quarter(2024, 2, nil) from
+// 1 April to 1 July UTC. A nil location means UTC.
+func Quarter(year, quarter int, loc *time.Location) Period {
+	return months(year, time.Month(3*(quarter-1)+1), 3, loc)
+}
+
+func months(year int, month time.Month, n int, loc *time.Location) Period {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return Period{Start: start, End: start.AddDate(0, n, 0), months: n}
+}
+
+// Contains reports whether the instant is within the period, the start included and the end excluded.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// Next returns the period following p, the next month of a month and the next quarter of a quarter, to close
+// the books period after period. The next period of a custom one has the same duration.
+func (p Period) Next() Period {
+	if p.months == 0 {
+		return Period{Start: p.End, End: p.End.Add(p.End.Sub(p.Start))}
+	}
+	return Period{Start: p.End, End: p.End.AddDate(0, p.months, 0), months: p.months}
+}
+
+type PeriodDate string
+
+const (
+	// transactions belong to the period they were created in, whatever their state
+	PeriodDate_CREATED PeriodDate = "created"
+	// transactions belong to the period they completed in; those not completed yet belong to none
+	PeriodDate_COMPLETED PeriodDate = "completed"
+)
+
+// PeriodOptions tunes PeriodTransactions.
+type PeriodOptions struct {
+	// the date placing the transactions in a period, created when empty
+	Date PeriodDate
+	// with PeriodDate_COMPLETED, how long before the period a transaction completed in it may have been created,
+	// 30 days when zero
+	Lookback time.Duration
+}
+
+// PeriodTransactions fetches the transactions of the period, e.g. for a month-end closing, and groups them by
+// account ID, with an entry for every account of the business, oldest first. A transaction moving funds between
+// accounts, such as an exchange, is listed under each of them. The API filters on the creation instant only, so
+// with PeriodDate_COMPLETED the transactions created up to opts.Lookback before the period are fetched as well,
+// and those completed outside of it are left out; a reverted transaction stays in the period it completed in.
+func PeriodTransactions(ctx context.Context, client *business.Client, period Period, opts PeriodOptions) (map[string][]*business.TransactionResp, error) {
+	date := opts.Date
+	if date == "" {
+		date = PeriodDate_CREATED
+	}
+	lookback := opts.Lookback
+	if lookback <= 0 {
+		lookback = defaultLookback
+	}
+	from := period.Start
+	if date == PeriodDate_COMPLETED {
+		from = from.Add(-lookback)
+	}
+
+	account, err := client.Account()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := account.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := client.Payment()
+	if err != nil {
+		return nil, err
+	}
+	txs, err := payments.ListAll(ctx, &business.TransactionReq{
+		From: from.UTC().Format(time.RFC3339Nano),
+		To:   period.End.UTC().Format(time.RFC3339Nano),
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string][]*business.TransactionResp, len(accounts))
+	for _, a := range accounts {
+		r[a.Id] = []*business.TransactionResp{}
+	}
+	for _, tx := range txs {
+		at, ok := periodDate(tx, date)
+		if !ok || !period.Contains(at) {
+			continue
+		}
+		// a transaction is listed once per account, even with several legs on it
+		seen := map[string]bool{}
+		for _, l := range tx.Legs {
+			if _, ok := r[l.AccountId]; !ok || seen[l.AccountId] {
+				continue
+			}
+			seen[l.AccountId] = true
+			r[l.AccountId] = append(r[l.AccountId], tx)
+		}
+	}
+	for _, list := range r {
+		sort.SliceStable(list, func(i, j int) bool {
+			a, _ := periodDate(list[i], date)
+			b, _ := periodDate(list[j], date)
+			return a.Before(b)
+		})
+	}
+
+	return r, nil
+}
+
+// periodDate returns the instant placing the transaction in a period, false when it has none
+func periodDate(tx *business.TransactionResp, date PeriodDate) (time.Time, bool) {
+	if date == PeriodDate_COMPLETED {
+		if tx.CompletedAt == nil {
+			return time.Time{}, false
+		}
+		return tx.CompletedAt.Time, true
+	}
+	return tx.CreatedAt.Time, true
+}
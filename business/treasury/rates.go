@@ -0,0 +1,205 @@
+package treasury
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// ErrNoSamples is returned by the rate statistics of a window without samples.
+var ErrNoSamples = errors.New("treasury: no rate samples in the window")
+
+// Pair is a currency pair to sample.
+type Pair struct {
+	From business.Currency `json:"from"`
+	To   business.Currency `json:"to"`
+}
+
+func (p Pair) String() string {
+	return string(p.From) + "/" + string(p.To)
+}
+
+// RateSample is an exchange rate read at an instant.
+type RateSample struct {
+	Pair Pair `json:"pair"`
+	// the rate of one unit of Pair.From in Pair.To
+	Rate float64 `json:"rate"`
+	// the date of the rate reported by the API
+	RateDate time.Time `json:"rate_date"`
+	// the instant the rate was read
+	TakenAt time.Time `json:"taken_at"`
+}
+
+// RateStore persists rate samples.
+type RateStore interface {
+	// Append stores the samples
+	Append(samples []*RateSample) error
+	// Range returns the samples of the pair taken in [from, to), oldest first
+	Range(pair Pair, from, to time.Time) ([]*RateSample, error)
+}
+
+// MemoryRateStore is an in-memory RateStore safe for concurrent use.
+type MemoryRateStore struct {
+	mu      sync.RWMutex
+	samples map[Pair][]*RateSample
+}
+
+func NewMemoryRateStore() *MemoryRateStore {
+	return &MemoryRateStore{samples: map[Pair][]*RateSample{}}
+}
+
+func (s *MemoryRateStore) Append(samples []*RateSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sample := range samples {
+		s.samples[sample.Pair] = append(s.samples[sample.Pair], sample)
+	}
+	return nil
+}
+
+func (s *MemoryRateStore) Range(pair Pair, from, to time.Time) ([]*RateSample, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var r []*RateSample
+	for _, sample := range s.samples[pair] {
+		if !sample.TakenAt.Before(from) && sample.TakenAt.Before(to) {
+			r = append(r, sample)
+		}
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].TakenAt.Before(r[j].TakenAt)
+	})
+	return r, nil
+}
+
+// RateStats aggregates the samples of a pair over a window.
+type RateStats struct {
+	Pair Pair `json:"pair"`
+	// the number of samples
+	Count int `json:"count"`
+	// the lowest rate
+	Min float64 `json:"min"`
+	// the highest rate
+	Max float64 `json:"max"`
+	// the mean rate
+	Avg float64 `json:"avg"`
+	// the oldest sample of the window
+	First *RateSample `json:"first"`
+	// the latest sample of the window
+	Last *RateSample `json:"last"`
+}
+
+// RateSampler reads the rates of currency pairs at a fixed interval and stores them.
+type RateSampler struct {
+	client   *business.Client
+	store    RateStore
+	interval time.Duration
+	pairs    []Pair
+}
+
+// NewRateSampler returns a sampler reading the pairs every interval.
+func NewRateSampler(client *business.Client, store RateStore, interval time.Duration, pairs ...Pair) *RateSampler {
+	return &RateSampler{
+		client:   client,
+		store:    store,
+		interval: interval,
+		pairs:    pairs,
+	}
+}
+
+// Run samples the rates every interval until the context is done.
+// Failed samples and warnings are reported to onError, which may be nil, and do not stop the schedule.
+func (s *RateSampler) Run(ctx context.Context, onError func(error)) error {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		_, warnings, err := s.Sample(ctx)
+		if onError == nil {
+			continue
+		}
+		if err != nil {
+			onError(err)
+		}
+		for _, w := range warnings {
+			onError(w)
+		}
+	}
+}
+
+// Sample reads and stores the current rate of every pair.
+// Pairs whose rate could not be read are skipped and returned as warnings.
+func (s *RateSampler) Sample(ctx context.Context) ([]*RateSample, business.Warnings, error) {
+	samples := make([]*RateSample, 0, len(s.pairs))
+	var warnings business.Warnings
+	exchange, err := s.client.Exchange()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range s.pairs {
+		rate, err := exchange.Rate(ctx, &business.ExchangeRateReq{From: p.From, To: p.To, Amount: 1})
+		if err != nil {
+			warnings.Add("exchange.rate", p.String(), err)
+			continue
+		}
+		samples = append(samples, &RateSample{
+			Pair:     p,
+			Rate:     rate.Rate,
+			RateDate: rate.RateDate.Time,
+			TakenAt:  time.Now(),
+		})
+	}
+
+	if len(samples) > 0 {
+		if err := s.store.Append(samples); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return samples, warnings, nil
+}
+
+// Stats returns the min, max and average rate of the pair sampled in [from, to), ErrNoSamples when there is none.
+func (s *RateSampler) Stats(pair Pair, from, to time.Time) (*RateStats, error) {
+	samples, err := s.store.Range(pair, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	stats := &RateStats{
+		Pair:  pair,
+		Count: len(samples),
+		Min:   samples[0].Rate,
+		Max:   samples[0].Rate,
+		First: samples[0],
+		Last:  samples[len(samples)-1],
+	}
+	sum := 0.0
+	for _, sample := range samples {
+		if sample.Rate < stats.Min {
+			stats.Min = sample.Rate
+		}
+		if sample.Rate > stats.Max {
+			stats.Max = sample.Rate
+		}
+		sum += sample.Rate
+	}
+	stats.Avg = sum / float64(len(samples))
+
+	return stats, nil
+}
@@ -0,0 +1,219 @@
+package treasury
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+type GapKind string
+
+const (
+	// the balance after a transaction does not follow from the balance before it and its amount,
+	// a transaction is missing in between
+	GapKind_MISSING_TRANSACTIONS GapKind = "missing_transactions"
+	// the closing balance does not match the current balance of the account
+	GapKind_CLOSING_MISMATCH GapKind = "closing_mismatch"
+)
+
+// Gap is a break in the balance roll-forward of an account.
+type Gap struct {
+	Kind GapKind `json:"kind"`
+	// the instant of the transaction after which the gap was found
+	At time.Time `json:"at"`
+	// the ID of the transaction after which the gap was found, empty for a closing mismatch
+	TransactionId string `json:"transaction_id,omitempty"`
+	// the balance the roll-forward expected
+	Expected float64 `json:"expected"`
+	// the balance reported by the API
+	Actual float64 `json:"actual"`
+}
+
+// RollForward is the balance roll-forward of an account over a period.
+type RollForward struct {
+	// the account ID
+	AccountId string `json:"account_id"`
+	// the account currency
+	Currency string `json:"currency"`
+	// the start of the period
+	From time.Time `json:"from"`
+	// the end of the period
+	To time.Time `json:"to"`
+	// the balance before the first transaction of the period, nil when unknown: no leg of the period reports its
+	// running balance and the period ends before now
+	Opening *float64 `json:"opening"`
+	// the sum of the incoming amounts
+	Credits float64 `json:"credits"`
+	// the sum of the outgoing amounts, as a positive number
+	Debits float64 `json:"debits"`
+	// the sum of the fees charged on the legs, as a positive number
+	Fees float64 `json:"fees"`
+	// the balance after the last transaction of the period, nil when the opening is unknown
+	Closing *float64 `json:"closing"`
+	// the breaks found in the roll-forward, empty when it reconciles
+	Gaps []Gap `json:"gaps,omitempty"`
+}
+
+// Reconciled reports whether the roll-forward has no gaps.
+func (r *RollForward) Reconciled() bool {
+	return len(r.Gaps) == 0
+}
+
+// Reconcile builds the balance roll-forward of every account over [from, to), from the current balances and
+// the completed and pending transactions of the period. The balances before and after the period are read from
+// the running balance of the transaction legs; without one, they are derived from the current balance of the
+// account when the period reaches the present, and unknown otherwise. A zero to means now.
+func Reconcile(ctx context.Context, client *business.Client, from, to time.Time) ([]*RollForward, error) {
+	now := time.Now()
+	if to.IsZero() {
+		to = now
+	}
+
+	account, err := client.Account()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := account.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := client.Payment()
+	if err != nil {
+		return nil, err
+	}
+	txs, err := payments.ListAll(ctx, &business.TransactionReq{
+		From: from.Format(time.RFC3339Nano),
+		To:   to.Format(time.RFC3339Nano),
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	legs := map[string][]leg{}
+	for _, tx := range txs {
+		if tx.State != business.PaymentState_COMPLETE && tx.State != business.PaymentState_PENDING {
+			continue
+		}
+		for i := range tx.Legs {
+			l := leg{tx: tx, leg: &tx.Legs[i]}
+			legs[l.leg.AccountId] = append(legs[l.leg.AccountId], l)
+		}
+	}
+
+	r := make([]*RollForward, 0, len(accounts))
+	for _, a := range accounts {
+		rf := &RollForward{
+			AccountId: a.Id,
+			Currency:  a.Currency,
+			From:      from,
+			To:        to,
+		}
+		// the current balance closes a period reaching the present
+		var current *float64
+		if !to.Before(now) {
+			current = &a.Balance
+		}
+		rf.roll(legs[a.Id], current)
+
+		if current != nil && rf.Closing != nil && !rf.balanceEqual(*rf.Closing, *current) {
+			rf.Gaps = append(rf.Gaps, Gap{
+				Kind:     GapKind_CLOSING_MISMATCH,
+				At:       now,
+				Expected: *rf.Closing,
+				Actual:   *current,
+			})
+		}
+
+		r = append(r, rf)
+	}
+
+	return r, nil
+}
+
+type leg struct {
+	tx  *business.TransactionResp
+	leg *business.TransactionLeg
+}
+
+// roll sums the legs oldest first, checking every running balance against the previous one. The opening balance
+// is backed out of the first leg returning its running balance or, without any, of current, the balance after the
+// last leg when known.
+func (rf *RollForward) roll(legs []leg, current *float64) {
+	sort.SliceStable(legs, func(i, j int) bool {
+		return legs[i].tx.CreatedAt.Before(legs[j].tx.CreatedAt.Time)
+	})
+
+	var opening *float64
+	var flows float64
+	for _, l := range legs {
+		flows = rf.round(flows + l.leg.Amount - fee(l.leg))
+		if l.leg.Balance != nil {
+			opening = rf.amount(*l.leg.Balance - flows)
+			break
+		}
+	}
+	// no leg reports its balance, the flows of every leg lead to the current balance
+	if opening == nil && current != nil {
+		opening = rf.amount(*current - flows)
+	}
+	rf.Opening = opening
+
+	var balance float64
+	if opening != nil {
+		balance = *opening
+	}
+	for _, l := range legs {
+		if l.leg.Amount < 0 {
+			rf.Debits -= l.leg.Amount
+		} else {
+			rf.Credits += l.leg.Amount
+		}
+
+		rf.Fees += fee(l.leg)
+
+		balance = rf.round(balance + l.leg.Amount - fee(l.leg))
+		if opening != nil && l.leg.Balance != nil && !rf.balanceEqual(balance, *l.leg.Balance) {
+			rf.Gaps = append(rf.Gaps, Gap{
+				Kind:          GapKind_MISSING_TRANSACTIONS,
+				At:            l.tx.CreatedAt.Time,
+				TransactionId: l.tx.Id,
+				Expected:      balance,
+				Actual:        *l.leg.Balance,
+			})
+			// carry on from the reported balance so a single gap is not reported again on every later leg
+			balance = *l.leg.Balance
+		}
+	}
+
+	rf.Credits, rf.Debits, rf.Fees = rf.round(rf.Credits), rf.round(rf.Debits), rf.round(rf.Fees)
+	if opening != nil {
+		rf.Closing = rf.amount(balance)
+	}
+}
+
+// fee returns the fee taken from the balance with the leg, 0 when none was charged
+func fee(l *business.TransactionLeg) float64 {
+	if l.Fee == nil {
+		return 0
+	}
+	return *l.Fee
+}
+
+// round rounds half up to the minor unit of the currency of the account
+func (rf *RollForward) round(amount float64) float64 {
+	return business.Currency(rf.Currency).Round(amount)
+}
+
+// amount returns the rounded amount, to report it
+func (rf *RollForward) amount(v float64) *float64 {
+	v = rf.round(v)
+	return &v
+}
+
+// balanceEqual reports whether the balances are equal in minor units of the currency of the account
+func (rf *RollForward) balanceEqual(a, b float64) bool {
+	return rf.round(a) == rf.round(b)
+}
@@ -0,0 +1,124 @@
+package treasury
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+func amount(v float64) *float64 {
+	return &v
+}
+
+func TestRoll(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	// legs builds one leg per transaction, an hour apart
+	legs := func(amounts ...business.TransactionLeg) []leg {
+		var r []leg
+		for i := range amounts {
+			tx := &business.TransactionResp{Id: string(rune('a' + i)), CreatedAt: business.Time{Time: start.Add(time.Duration(i) * time.Hour)}}
+			tx.Legs = []business.TransactionLeg{amounts[i]}
+			r = append(r, leg{tx: tx, leg: &tx.Legs[0]})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		legs    []leg
+		current *float64
+		want    RollForward
+	}{
+		{
+			name: "running balances",
+			legs: legs(
+				business.TransactionLeg{Amount: 100, Balance: amount(1100)},
+				business.TransactionLeg{Amount: -50, Fee: amount(1.5), Balance: amount(1048.5)},
+			),
+			current: amount(1048.5),
+			want:    RollForward{Opening: amount(1000), Credits: 100, Debits: 50, Fees: 1.5, Closing: amount(1048.5)},
+		},
+		{
+			name: "balance on a later leg only",
+			legs: legs(
+				business.TransactionLeg{Amount: 100},
+				business.TransactionLeg{Amount: -50, Balance: amount(1050)},
+			),
+			want: RollForward{Opening: amount(1000), Credits: 100, Debits: 50, Closing: amount(1050)},
+		},
+		{
+			name: "missing transaction",
+			legs: legs(
+				business.TransactionLeg{Amount: 100, Balance: amount(1100)},
+				business.TransactionLeg{Amount: -50, Balance: amount(1030)},
+			),
+			want: RollForward{Opening: amount(1000), Credits: 100, Debits: 50, Closing: amount(1030), Gaps: []Gap{{
+				Kind:          GapKind_MISSING_TRANSACTIONS,
+				At:            start.Add(time.Hour),
+				TransactionId: "b",
+				Expected:      1050,
+				Actual:        1030,
+			}}},
+		},
+		{
+			name: "no balances, derived from the current balance",
+			legs: legs(
+				business.TransactionLeg{Amount: 100},
+				business.TransactionLeg{Amount: -50, Fee: amount(0.5)},
+			),
+			current: amount(1049.5),
+			want:    RollForward{Opening: amount(1000), Credits: 100, Debits: 50, Fees: 0.5, Closing: amount(1049.5)},
+		},
+		{
+			name: "no balances in the past",
+			legs: legs(
+				business.TransactionLeg{Amount: 100},
+				business.TransactionLeg{Amount: -50},
+			),
+			want: RollForward{Credits: 100, Debits: 50},
+		},
+		{
+			name:    "no transactions",
+			current: amount(1000),
+			want:    RollForward{Opening: amount(1000), Closing: amount(1000)},
+		},
+		{
+			name: "no transactions in the past",
+			want: RollForward{},
+		},
+		{
+			name: "rounded to the currency",
+			legs: legs(
+				business.TransactionLeg{Amount: 0.1, Balance: amount(0.1)},
+				business.TransactionLeg{Amount: 0.2, Balance: amount(0.3)},
+			),
+			want: RollForward{Opening: amount(0), Credits: 0.3, Closing: amount(0.3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf := &RollForward{Currency: "GBP"}
+			rf.roll(tt.legs, tt.current)
+
+			tt.want.Currency = "GBP"
+			if !reflect.DeepEqual(*rf, tt.want) {
+				t.Errorf("got %s, want %s", format(rf), format(&tt.want))
+			}
+		})
+	}
+}
+
+// format prints the roll-forward with its balances dereferenced
+func format(rf *RollForward) string {
+	value := func(v *float64) string {
+		if v == nil {
+			return "unknown"
+		}
+		return fmt.Sprint(*v)
+	}
+	return fmt.Sprintf("opening %s, credits %v, debits %v, fees %v, closing %s, gaps %+v",
+		value(rf.Opening), rf.Credits, rf.Debits, rf.Fees, value(rf.Closing), rf.Gaps)
+}
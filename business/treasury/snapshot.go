@@ -0,0 +1,207 @@
+package treasury
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// DateLayout is the layout of the snapshot dates
+const DateLayout = "2006-01-02"
+
+// BalanceSnapshot is the balance of an account at the end of a day.
+type BalanceSnapshot struct {
+	// the day of the snapshot in the snapshotter's time zone, formatted with DateLayout
+	Date string `json:"date"`
+	// the account ID
+	AccountId string `json:"account_id"`
+	// the account name
+	Name string `json:"name"`
+	// the account currency
+	Currency string `json:"currency"`
+	// the balance at the cut-off time
+	Balance float64 `json:"balance"`
+	// the balance converted into the reporting currency, nil without one or when the conversion failed
+	Reported *business.Amount `json:"reported,omitempty"`
+	// the instant the balance was read
+	TakenAt time.Time `json:"taken_at"`
+}
+
+// SnapshotStore persists balance snapshots by date.
+type SnapshotStore interface {
+	// Save stores the snapshots of a date, replacing any earlier ones for the same accounts
+	Save(snapshots []*BalanceSnapshot) error
+	// Load returns the snapshots of a date, empty when none were taken
+	Load(date string) ([]*BalanceSnapshot, error)
+}
+
+// MemorySnapshotStore is an in-memory SnapshotStore safe for concurrent use.
+type MemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]map[string]*BalanceSnapshot
+}
+
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: map[string]map[string]*BalanceSnapshot{}}
+}
+
+func (s *MemorySnapshotStore) Save(snapshots []*BalanceSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, snapshot := range snapshots {
+		if s.snapshots[snapshot.Date] == nil {
+			s.snapshots[snapshot.Date] = map[string]*BalanceSnapshot{}
+		}
+		s.snapshots[snapshot.Date][snapshot.AccountId] = snapshot
+	}
+	return nil
+}
+
+func (s *MemorySnapshotStore) Load(date string) ([]*BalanceSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := make([]*BalanceSnapshot, 0, len(s.snapshots[date]))
+	for _, snapshot := range s.snapshots[date] {
+		r = append(r, snapshot)
+	}
+	return r, nil
+}
+
+// Snapshotter records the balance of every account once a day at a cut-off time.
+type Snapshotter struct {
+	client *business.Client
+	store  SnapshotStore
+	cutOff time.Duration
+	loc    *time.Location
+	// the currency balances are also reported in, none when empty
+	reporting string
+}
+
+// NewSnapshotter returns a snapshotter taking snapshots cutOff after midnight in the given location,
+// e.g. 17*time.Hour+30*time.Minute for 17:30. A nil location means UTC.
+func NewSnapshotter(client *business.Client, store SnapshotStore, cutOff time.Duration, loc *time.Location) *Snapshotter {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return &Snapshotter{
+		client: client,
+		store:  store,
+		cutOff: cutOff,
+		loc:    loc,
+	}
+}
+
+// WithReportingCurrency makes every snapshot also carry its balance converted into currency.
+func (s *Snapshotter) WithReportingCurrency(currency string) *Snapshotter {
+	s.reporting = currency
+	return s
+}
+
+// Run takes a snapshot at every cut-off until the context is done.
+// Failed snapshots and warnings are reported to onError, which may be nil, and do not stop the schedule.
+func (s *Snapshotter) Run(ctx context.Context, onError func(error)) error {
+	for {
+		t := time.NewTimer(time.Until(s.next(time.Now())))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		_, warnings, err := s.Snapshot(ctx)
+		if onError == nil {
+			continue
+		}
+		if err != nil {
+			onError(err)
+		}
+		for _, w := range warnings {
+			onError(w)
+		}
+	}
+}
+
+// Snapshot reads and stores the current balances of all accounts, dated with the current day.
+// Failed conversions into the reporting currency leave Reported nil and are returned as warnings.
+func (s *Snapshotter) Snapshot(ctx context.Context) ([]*BalanceSnapshot, business.Warnings, error) {
+	account, err := s.client.Account()
+	if err != nil {
+		return nil, nil, err
+	}
+	accounts, err := account.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	date := now.In(s.loc).Format(DateLayout)
+	snapshots := make([]*BalanceSnapshot, 0, len(accounts))
+	var warnings business.Warnings
+	for _, a := range accounts {
+		snapshot := &BalanceSnapshot{
+			Date:      date,
+			AccountId: a.Id,
+			Name:      a.Name,
+			Currency:  a.Currency,
+			Balance:   a.Balance,
+			TakenAt:   now,
+		}
+		if s.reporting != "" {
+			snapshot.Reported, err = s.report(ctx, a.Balance, a.Currency)
+			if err != nil {
+				warnings.Add("exchange.rate", a.Id+" reported balance", err)
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := s.store.Save(snapshots); err != nil {
+		return nil, nil, err
+	}
+
+	return snapshots, warnings, nil
+}
+
+func (s *Snapshotter) report(ctx context.Context, balance float64, currency string) (*business.Amount, error) {
+	if currency == s.reporting || balance == 0 {
+		return &business.Amount{Amount: balance, Currency: s.reporting}, nil
+	}
+
+	// the rate endpoint only prices positive amounts
+	sign := 1.0
+	if balance < 0 {
+		sign = -1
+	}
+	exchange, err := s.client.Exchange()
+	if err != nil {
+		return nil, err
+	}
+	rate, err := exchange.Rate(ctx, &business.ExchangeRateReq{From: business.Currency(currency), To: business.Currency(s.reporting), Amount: sign * balance})
+	if err != nil {
+		return nil, err
+	}
+
+	return &business.Amount{Amount: sign * rate.To.Amount, Currency: s.reporting}, nil
+}
+
+// At returns the snapshots taken for the day of the given date in the snapshotter's time zone.
+func (s *Snapshotter) At(date time.Time) ([]*BalanceSnapshot, error) {
+	return s.store.Load(date.In(s.loc).Format(DateLayout))
+}
+
+// next returns the first cut-off after now. The cut-off is a wall clock time, so it holds across DST changes.
+func (s *Snapshotter) next(now time.Time) time.Time {
+	local := now.In(s.loc)
+	for day := 0; ; day++ {
+		next := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, int(s.cutOff), s.loc)
+		if next.After(now) {
+			return next
+		}
+	}
+}
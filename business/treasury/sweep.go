@@ -0,0 +1,171 @@
+package treasury
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+// SweepRule caps the balance of an account and moves the surplus to another account of the business,
+// exchanging it when the target account holds another currency.
+type SweepRule struct {
+	// the ID of the account to sweep
+	AccountId string
+	// the balance to keep on the account, in its currency
+	Keep float64
+	// the ID of the account receiving the surplus
+	TargetAccountId string
+	// the smallest surplus worth moving, smaller ones are skipped
+	MinAmount float64
+	// an optional reference of the transfer or exchange
+	Reference string
+}
+
+// maxRequestIdLength is the longest request ID the API accepts
+const maxRequestIdLength = 40
+
+// SweepOptions configures a sweep run.
+type SweepOptions struct {
+	// plan the moves without executing them
+	DryRun bool
+	// the idempotency key of the run, used in the request IDs so that running the same sweep again with
+	// the same key does not move the funds twice; the current UTC date when empty, i.e. one sweep a day. The
+	// request IDs are "sweep-<Key>-<rule hash>", at most 40 characters, the hash standing for the accounts and
+	// currency of the rule so that reordering the rules keeps their request IDs
+	Key string
+}
+
+// SweepMove is the move planned or made for a rule.
+type SweepMove struct {
+	Rule *SweepRule
+	// the balance of the swept account when the sweep ran
+	Balance float64
+	// the amount moved, in the currency of the swept account; zero when there was no surplus to move
+	Amount float64
+	// the transfer planned or made between accounts of the same currency
+	Transfer *business.TransferReq
+	// the exchange planned or made between accounts of different currencies
+	Exchange *business.ExchangeReq
+	// the result of the transfer, nil on a dry run
+	Transferred *business.TransferResp
+	// the result of the exchange, nil on a dry run
+	Exchanged *business.ExchangeResp
+	// the error of the move, the sweep carries on with the next rule
+	Err error
+}
+
+// Sweep reads the balances of the accounts and moves the surplus of every rule, in order, rounded with the
+// rounding policy of the client. Rules naming unknown accounts, rules sweeping the same account to the same target
+// twice and keys too long for the request IDs fail before anything moves; failed moves are reported on their
+// SweepMove. A transfer credits its target account, so a later rule sweeping it moves what it received too.
+func Sweep(ctx context.Context, client *business.Client, rules []*SweepRule, opts SweepOptions) ([]*SweepMove, error) {
+	if opts.Key == "" {
+		opts.Key = time.Now().UTC().Format("20060102")
+	}
+	if n := len(requestId(opts.Key, &business.AccountResp{}, &business.AccountResp{})); n > maxRequestIdLength {
+		return nil, fmt.Errorf("treasury: the sweep key %q makes request IDs of %d characters, %d max", opts.Key, n, maxRequestIdLength)
+	}
+
+	account, err := client.Account()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := account.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[string]*business.AccountResp, len(accounts))
+	for _, a := range accounts {
+		byId[a.Id] = a
+	}
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		for _, id := range []string{rule.AccountId, rule.TargetAccountId} {
+			if byId[id] == nil {
+				return nil, fmt.Errorf("treasury: sweep account %s not found", id)
+			}
+		}
+		// the request ID stands for the accounts of the rule, a second rule would replay the move of the first
+		id := requestId(opts.Key, byId[rule.AccountId], byId[rule.TargetAccountId])
+		if seen[id] {
+			return nil, fmt.Errorf("treasury: account %s is swept to %s by more than one rule", rule.AccountId, rule.TargetAccountId)
+		}
+		seen[id] = true
+	}
+
+	rounding := client.Rounding()
+	moves := make([]*SweepMove, 0, len(rules))
+	for _, rule := range rules {
+		from, to := byId[rule.AccountId], byId[rule.TargetAccountId]
+		move := &SweepMove{Rule: rule, Balance: from.Balance}
+		moves = append(moves, move)
+
+		decimals := business.Currency(from.Currency).Decimals()
+		surplus := rounding.Round(ctx, "treasury.sweep", from.Balance-rule.Keep, decimals)
+		if surplus <= 0 || surplus < rule.MinAmount {
+			continue
+		}
+		move.Amount = surplus
+
+		if from.Currency == to.Currency {
+			move.Transfer = &business.TransferReq{
+				RequestId:       requestId(opts.Key, from, to),
+				SourceAccountId: from.Id,
+				TargetAccountId: to.Id,
+				Amount:          surplus,
+				Currency:        from.Currency,
+				Reference:       rule.Reference,
+			}
+		} else {
+			move.Exchange = &business.ExchangeReq{
+				From:      business.ExchangeAmount{AccountId: from.Id, Currency: business.Currency(from.Currency), Amount: surplus},
+				To:        business.ExchangeAmount{AccountId: to.Id, Currency: business.Currency(to.Currency)},
+				Reference: rule.Reference,
+				RequestId: requestId(opts.Key, from, to),
+			}
+		}
+		if !opts.DryRun {
+			move.Err = execute(ctx, client, move)
+		}
+		// later rules on the same accounts see the balances left by this one; the amount an exchange buys is
+		// only known once it completes
+		if move.Err == nil {
+			from.Balance = rounding.Round(ctx, "treasury.sweep", from.Balance-surplus, decimals)
+			if move.Transfer != nil {
+				to.Balance = rounding.Round(ctx, "treasury.sweep", to.Balance+surplus, decimals)
+			}
+		}
+	}
+
+	return moves, nil
+}
+
+// requestId returns the request ID of the move of a sweep between two accounts, from a hash of the accounts and
+// their currencies as account IDs are too long for the 40 characters of a request ID
+func requestId(key string, from, to *business.AccountResp) string {
+	h := sha256.Sum256([]byte(from.Id + "/" + from.Currency + ">" + to.Id + "/" + to.Currency))
+	return "sweep-" + key + "-" + hex.EncodeToString(h[:6])
+}
+
+// execute makes the transfer or the exchange of the move.
+func execute(ctx context.Context, client *business.Client, move *SweepMove) error {
+	if move.Transfer != nil {
+		transfers, err := client.Transfer()
+		if err != nil {
+			return err
+		}
+		move.Transferred, err = transfers.Create(ctx, move.Transfer)
+		return err
+	}
+
+	exchange, err := client.Exchange()
+	if err != nil {
+		return err
+	}
+	move.Exchanged, err = exchange.Exchange(ctx, move.Exchange)
+	return err
+}
@@ -0,0 +1,99 @@
+package treasury
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/revoluttest"
+)
+
+func sweepServer() *revoluttest.Server {
+	return revoluttest.NewServer(revoluttest.Fixtures{
+		Accounts: []*business.AccountResp{
+			{Id: "gbp", Currency: "GBP", Balance: 100},
+			{Id: "savings", Currency: "GBP", Balance: 0},
+			{Id: "eur", Currency: "EUR", Balance: 50},
+		},
+		Rates: map[string]float64{"EUR/GBP": 0.85},
+	})
+}
+
+func TestSweepRequestIds(t *testing.T) {
+	s := sweepServer()
+	defer s.Close()
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	toSavings := &SweepRule{AccountId: "gbp", Keep: 10, TargetAccountId: "savings"}
+	toGbp := &SweepRule{AccountId: "eur", Keep: 20, TargetAccountId: "gbp"}
+
+	ids := func(rules ...*SweepRule) map[*SweepRule]string {
+		moves, err := Sweep(context.Background(), c, rules, SweepOptions{DryRun: true, Key: "20240301"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := map[*SweepRule]string{}
+		for _, m := range moves {
+			switch {
+			case m.Transfer != nil:
+				r[m.Rule] = m.Transfer.RequestId
+			case m.Exchange != nil:
+				r[m.Rule] = m.Exchange.RequestId
+			}
+			if id := r[m.Rule]; len(id) > maxRequestIdLength || !strings.HasPrefix(id, "sweep-20240301-") {
+				t.Errorf("got request ID %q", id)
+			}
+		}
+		return r
+	}
+	ordered, reordered := ids(toSavings, toGbp), ids(toGbp, toSavings)
+	if ordered[toSavings] == ordered[toGbp] {
+		t.Errorf("got request ID %q for both rules", ordered[toSavings])
+	}
+	for _, rule := range []*SweepRule{toSavings, toGbp} {
+		if ordered[rule] != reordered[rule] {
+			t.Errorf("got request ID %q once reordered, want %q", reordered[rule], ordered[rule])
+		}
+	}
+
+	_, err = Sweep(context.Background(), c, []*SweepRule{toSavings, {AccountId: "gbp", Keep: 50, TargetAccountId: "savings"}}, SweepOptions{DryRun: true})
+	if err == nil {
+		t.Error("got no error for two rules sweeping the same accounts")
+	}
+	_, err = Sweep(context.Background(), c, []*SweepRule{toSavings}, SweepOptions{DryRun: true, Key: strings.Repeat("k", 22)})
+	if err == nil {
+		t.Error("got no error for a key too long for the request IDs")
+	}
+}
+
+func TestSweepRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		mode business.RoundingMode
+		want float64
+	}{
+		{"half up", business.RoundingMode_HALF_UP, 90},
+		{"truncate", business.RoundingMode_TRUNCATE, 89.99},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := sweepServer()
+			defer s.Close()
+			c, err := s.Client(business.WithRoundingMode(tt.mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules := []*SweepRule{{AccountId: "gbp", Keep: 10.004, TargetAccountId: "savings"}}
+			moves, err := Sweep(context.Background(), c, rules, SweepOptions{DryRun: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if moves[0].Amount != tt.want {
+				t.Errorf("got %v swept, want %v", moves[0].Amount, tt.want)
+			}
+		})
+	}
+}
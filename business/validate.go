@@ -0,0 +1,230 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// the longest request_id the API accepts
+	maxRequestIdLength = 40
+	// the longest payment, transfer or exchange reference the API accepts
+	maxReferenceLength = 100
+)
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ValidationError reports a request that breaks a documented constraint, detected before it is sent.
+type ValidationError struct {
+	// the JSON name of the offending field
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation: %s %s", e.Field, e.Reason)
+}
+
+func invalid(field, reason string) error {
+	return &ValidationError{Field: field, Reason: reason}
+}
+
+func validateRequestId(id string) error {
+	if len(id) > maxRequestIdLength {
+		return invalid("request_id", fmt.Sprintf("is longer than %d characters", maxRequestIdLength))
+	}
+	return nil
+}
+
+func validateReference(reference string) error {
+	if len(reference) > maxReferenceLength {
+		return invalid("reference", fmt.Sprintf("is longer than %d characters", maxReferenceLength))
+	}
+	return nil
+}
+
+func validateCurrency(field string, c Currency) error {
+	if err := c.Validate(); err != nil {
+		return invalid(field, fmt.Sprintf("%q is not an ISO 4217 currency code", string(c)))
+	}
+	return nil
+}
+
+// validateDecimals checks that the amount has no more decimals than the minor unit of the currency.
+func validateDecimals(field string, amount float64, c Currency) error {
+	if c.Round(amount) != amount {
+		return invalid(field, fmt.Sprintf("has more than %d decimals for %s", c.Decimals(), string(c)))
+	}
+	return nil
+}
+
+func required(field, value string) error {
+	if value == "" {
+		return invalid(field, "is required")
+	}
+	return nil
+}
+
+func positive(field string, amount float64) error {
+	if amount <= 0 {
+		return invalid(field, "must be positive")
+	}
+	return nil
+}
+
+// firstErr returns the first non nil error.
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks the constraints of the rate endpoint.
+func (r *ExchangeRateReq) Validate() error {
+	if r.Amount < 0 {
+		return invalid("amount", "cannot be negative")
+	}
+	// a positive amount must not be sent as zero, e.g. 0.3 JPY
+	if r.Amount > 0 && r.From.Round(r.Amount) == 0 {
+		return invalid("amount", fmt.Sprintf("rounds to zero for %s", string(r.From)))
+	}
+	if r.Exact != "" {
+		if r.Amount != 0 {
+			return invalid("amount", "cannot be set with the exact amount")
+		}
+		if v, ok := r.Exact.Rat(); !ok || v.Sign() <= 0 {
+			return invalid("amount", "must be a positive decimal")
+		}
+	}
+	return firstErr(
+		validateCurrency("from", r.From),
+		validateCurrency("to", r.To),
+	)
+}
+
+// Validate checks the constraints of an exchange, including that exactly one of the amounts is set.
+func (r *ExchangeReq) Validate() error {
+	switch {
+	case r.From.Amount == 0 && r.To.Amount == 0:
+		return invalid("from.amount", "or to.amount is required")
+	case r.From.Amount != 0 && r.To.Amount != 0:
+		return invalid("to.amount", "cannot be set with from.amount")
+	}
+	if r.From.Amount < 0 || r.To.Amount < 0 {
+		return invalid("amount", "must be positive")
+	}
+	if r.From.Currency == r.To.Currency {
+		return invalid("to.currency", "must differ from from.currency")
+	}
+	return firstErr(
+		required("from.account_id", r.From.AccountId),
+		required("to.account_id", r.To.AccountId),
+		validateCurrency("from.currency", r.From.Currency),
+		validateCurrency("to.currency", r.To.Currency),
+		validateDecimals("from.amount", r.From.Amount, r.From.Currency),
+		validateDecimals("to.amount", r.To.Amount, r.To.Currency),
+		validateRequestId(r.RequestId),
+		validateReference(r.Reference),
+	)
+}
+
+// Validate checks the constraints of a payment.
+func (r *PaymentReq) Validate() error {
+	if r.ChargeBearer != "" && r.ChargeBearer != ChargeBearer_SHARED && r.ChargeBearer != ChargeBearer_DEBTOR {
+		return invalid("charge_bearer", "must be shared or debtor")
+	}
+	if r.Receiver.CardId != "" && r.Receiver.AccountId != "" {
+		return invalid("receiver.card_id", "cannot be set with receiver.account_id")
+	}
+	return firstErr(
+		required("account_id", r.AccountId),
+		required("receiver.counterparty_id", r.Receiver.CounterpartyId),
+		positive("amount", r.Amount),
+		validateCurrency("currency", Currency(r.Currency)),
+		validateDecimals("amount", r.Amount, Currency(r.Currency)),
+		validateRequestId(r.RequestId),
+		validateReference(r.Reference),
+	)
+}
+
+// Validate checks the constraints of a transfer between accounts of the business.
+func (r *TransferReq) Validate() error {
+	if r.SourceAccountId != "" && r.SourceAccountId == r.TargetAccountId {
+		return invalid("target_account_id", "must differ from source_account_id")
+	}
+	return firstErr(
+		required("source_account_id", r.SourceAccountId),
+		required("target_account_id", r.TargetAccountId),
+		positive("amount", r.Amount),
+		validateCurrency("currency", Currency(r.Currency)),
+		validateDecimals("amount", r.Amount, Currency(r.Currency)),
+		validateRequestId(r.RequestId),
+		validateReference(r.Reference),
+	)
+}
+
+// Validate checks that the fields required by the profile type are set.
+func (r *RevolutCounterpartyReq) Validate() error {
+	switch r.ProfileType {
+	case CounterpartyProfileType_BUSINESS:
+		return required("email", r.Email)
+	case CounterpartyProfileType_PERSONAL:
+		return firstErr(
+			required("name", r.Name),
+			required("phone", r.Phone),
+		)
+	}
+	return invalid("profile_type", "must be business or personal")
+}
+
+// Validate checks that the counterparty is named, its bank country and currency are valid, and the bank
+// details they require are set, see validateBankDetails.
+func (r *NonRevolutCounterpartyReq) Validate() error {
+	if r.CompanyName == "" && (r.IndividualName.FirstName == "" || r.IndividualName.LastName == "") {
+		return invalid("company_name", "or individual_name must be set")
+	}
+	if !countryCode.MatchString(r.BankCountry) {
+		return invalid("bank_country", "must be an ISO 3166-1 alpha-2 code")
+	}
+	return firstErr(
+		validateCurrency("currency", Currency(r.Currency)),
+		r.validateBankDetails(),
+	)
+}
+
+// Validate checks every planned payment, which must all be paid from the same account.
+func (r *PaymentDraftReq) Validate() error {
+	if len(r.Payments) == 0 {
+		return invalid("payments", "is required")
+	}
+	for i, p := range r.Payments {
+		field := fmt.Sprintf("payments[%d].", i)
+		if p.Amount <= 0 {
+			return invalid(field+"amount", "must be positive")
+		}
+		if p.AccountId != r.Payments[0].AccountId {
+			return invalid(field+"account_id", "must be the same for all payments")
+		}
+		if err := firstErr(
+			required(field+"account_id", p.AccountId),
+			required(field+"receiver.counterparty_id", p.Receiver.CounterpartyId),
+			required(field+"reference", p.Reference),
+			validateCurrency(field+"currency", Currency(p.Currency)),
+			validateReference(p.Reference),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks the page size of a transaction list.
+func (r *TransactionReq) Validate() error {
+	if r.Count < 0 || r.Count > maxTransactionCount {
+		return invalid("count", fmt.Sprintf("must be between 0 and %d", maxTransactionCount))
+	}
+	return nil
+}
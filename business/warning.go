@@ -0,0 +1,29 @@
+package business
+
+import "fmt"
+
+// Warning reports an auxiliary call that failed while a composite helper still produced its primary result,
+// such as a display conversion or a counterparty lookup.
+type Warning struct {
+	// the operation that failed, e.g. exchange.rate
+	Operation string `json:"operation"`
+	// what the result lacks because of the failure
+	Subject string `json:"subject"`
+	Err     error  `json:"-"`
+}
+
+func (w *Warning) Error() string {
+	return fmt.Sprintf("%s (%s): %v", w.Operation, w.Subject, w.Err)
+}
+
+func (w *Warning) Unwrap() error {
+	return w.Err
+}
+
+// Warnings lists the auxiliary failures of a composite helper.
+type Warnings []*Warning
+
+// Add records an auxiliary failure.
+func (ws *Warnings) Add(operation, subject string, err error) {
+	*ws = append(*ws, &Warning{Operation: operation, Subject: subject, Err: err})
+}
@@ -1,25 +1,24 @@
 package business
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"time"
 
-	"github.com/quiver-london/go-revolut/business/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/business/request"
 )
 
 type WebhookService struct {
 	accessToken string
 	sandbox     bool
-
-	err error
+	options     *request.Options
 }
 
 type TransactionStateChangedEvent struct {
 	// the event name
 	Event string `json:"event"`
 	// the event time
-	Timestamp time.Time                        `json:"timestamp"`
+	Timestamp Time                             `json:"timestamp"`
 	Data      TransactionStateChangedEventData `json:"data"`
 }
 
@@ -27,55 +26,60 @@ type TransactionStateChangedEventData struct {
 	// the ID of the transaction
 	ID string `json:"id"`
 	// previous state of the transaction
-	OldState string `json:"old_state"`
+	OldState PaymentState `json:"old_state"`
 	// new state of the transaction
-	NewState string `json:"new_state"`
+	NewState PaymentState `json:"new_state"`
 }
 
 type TransactionCreatedEvent struct {
 	// the event name
 	Event string `json:"event"`
 	// the event time
-	Timestamp time.Time                   `json:"timestamp"`
+	Timestamp Time                        `json:"timestamp"`
 	Data      TransactionCreatedEventData `json:"data"`
 }
 
 type TransactionCreatedEventData struct {
 	// the ID of transaction
-	Id   string `json:"id"`
-	Type string `json:"type"`
+	Id   string      `json:"id"`
+	Type PaymentType `json:"type"`
 	// the client provided request ID
 	RequestId string `json:"request_id"`
 	// the transction state: pending, completed, declined or failed
 	State PaymentState `json:"state"`
 	// an optional reason code for declined or failed transaction state
-	ReasonCode string `json:"reason_code"`
+	ReasonCode ReasonCode `json:"reason_code"`
 	// the instant when the transaction was created
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Time `json:"created_at"`
 	// the instant when the transaction was last updated
-	UpdatedAt time.Time `json:"updated_at"`
-	// the instant when the transaction was completed, mandatory for completed state only
-	CompletedAt time.Time `json:"completed_at"`
+	UpdatedAt Time `json:"updated_at"`
+	// the instant when the transaction was completed, nil unless completed
+	CompletedAt *Time `json:"completed_at,omitempty"`
 	// an optional date when the transaction was scheduled for
 	ScheduledFor string `json:"scheduled_for"`
 	// a user provided payment reference
 	Reference string `json:"reference"`
 	// the legs of transaction, there'll be 2 legs between your Revolut accounts and 1 leg in other cases
 	Legs []TransactionLeg `json:"legs"`
+	// the merchant info, nil but for card transactions
+	Merchant *TransactionMerchant `json:"merchant,omitempty"`
+	// the card information, nil but for card transactions
+	Card *TransactionCard `json:"card,omitempty"`
+	// the ID of the original transaction which has been refunded (only for refunds)
+	RelatedTransactionId string `json:"related_transaction_id,omitempty"`
 }
 
 // Set:
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#web-hooks-setting-up-a-web-hook
-func (p *WebhookService) Set(url string) error {
-	if p.err != nil {
-		return p.err
-	}
-
+func (p *WebhookService) Set(ctx context.Context, url string) error {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "webhook.set",
 		Method:      http.MethodPost,
-		Url:         "https://b2b.revolut.com/api/1.0/webhook",
+		Url:         endpoint(nil, "webhook"),
 		AccessToken: p.accessToken,
 		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
 		Body: struct {
 			// call back endpoint of the client system, https is the supported protocol
 			Url string `json:"url"`
@@ -94,16 +98,15 @@ func (p *WebhookService) Set(url string) error {
 
 // Delete: Use this API request to delete a web-hook
 // doc: https://revolut-engineering.github.io/api-docs/business-api/#web-hooks-setting-up-a-web-hook
-func (p *WebhookService) Delete() error {
-	if p.err != nil {
-		return p.err
-	}
-
+func (p *WebhookService) Delete(ctx context.Context) error {
 	resp, statusCode, err := request.New(request.Config{
+		Operation:   "webhook.delete",
 		Method:      http.MethodDelete,
-		Url:         "https://b2b.revolut.com/api/1.0/webhook",
+		Url:         endpoint(nil, "webhook"),
 		AccessToken: p.accessToken,
 		Sandbox:     p.sandbox,
+		Options:     p.options,
+		Context:     ctx,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,74 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Backfill replays the webhook events of the transactions created since the given instant, oldest first, for
+// the deliveries missed while the webhook URL was unreachable. Every transaction yields a TransactionCreated
+// event and, once it left the pending state, a TransactionStateChanged event from pending to its current state.
+// Events the dedupe store of the subscriber already saw are skipped, so Backfill can run on every start-up;
+// without a store every event is dispatched. Transactions created before since but updated after are not visited.
+// It stops at the first event a consumer fails to process, which the next run dispatches again.
+func (s *WebhookSubscriber) Backfill(ctx context.Context, client *Client, since time.Time) error {
+	payments, err := client.Payment()
+	if err != nil {
+		return err
+	}
+	txs, err := payments.ListAll(ctx, &TransactionReq{From: since.Format(time.RFC3339Nano)}).All()
+	if err != nil {
+		return err
+	}
+
+	for i := len(txs) - 1; i >= 0; i-- {
+		events, err := backfillEvents(txs[i])
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := s.Dispatch(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// backfillEvents synthesises the webhook events of a transaction. Like the webhook call it replaces, the
+// TransactionCreated event carries the transaction as created, pending; its current state comes with the
+// TransactionStateChanged event.
+func backfillEvents(tx *TransactionResp) ([]*WebhookEvent, error) {
+	created, err := json.Marshal(&TransactionCreatedEventData{
+		Id:                   tx.Id,
+		Type:                 tx.Type,
+		RequestId:            tx.RequestId,
+		State:                PaymentState_PENDING,
+		CreatedAt:            tx.CreatedAt,
+		UpdatedAt:            tx.CreatedAt,
+		ScheduledFor:         tx.ScheduledFor,
+		Reference:            tx.Reference,
+		Legs:                 tx.Legs,
+		Merchant:             tx.Merchant,
+		Card:                 tx.Card,
+		RelatedTransactionId: tx.RelatedTransactionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := []*WebhookEvent{{Event: WebhookEventName_TRANSACTION_CREATED, Timestamp: tx.CreatedAt, Data: created}}
+
+	if tx.State != PaymentState_PENDING {
+		changed, err := json.Marshal(&TransactionStateChangedEventData{
+			ID:       tx.Id,
+			OldState: PaymentState_PENDING,
+			NewState: tx.State,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &WebhookEvent{Event: WebhookEventName_TRANSACTION_STATE_CHANGED, Timestamp: tx.UpdatedAt, Data: changed})
+	}
+	return events, nil
+}
@@ -0,0 +1,151 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the header carrying the signatures of a webhook call, comma separated "v1=<hex>" values
+	WebhookSignatureHeader = "Revolut-Signature"
+	// the header carrying the instant of a webhook call, in Unix milliseconds
+	WebhookTimestampHeader = "Revolut-Request-Timestamp"
+
+	// DefaultWebhookTolerance bounds how old a webhook call may be, rejecting replays
+	DefaultWebhookTolerance = 5 * time.Minute
+	// DefaultWebhookMaxBody bounds the body of a webhook call read by VerifyRequest, in bytes
+	DefaultWebhookMaxBody = 1 << 20
+
+	webhookSignatureVersion = "v1"
+)
+
+var (
+	// ErrWebhookSignature is returned for a webhook call signed with none of the active secrets.
+	ErrWebhookSignature = errors.New("webhook: no valid signature")
+	// ErrWebhookNoSecret is returned when no signing secret is active, e.g. an unset environment variable: every
+	// call is rejected rather than checked against an empty key anyone can sign with.
+	ErrWebhookNoSecret = errors.New("webhook: no signing secret configured")
+	// ErrWebhookBodyTooLarge is returned by VerifyRequest for a body larger than the limit of the verifier.
+	ErrWebhookBodyTooLarge = errors.New("webhook: body too large")
+)
+
+// WebhookSecretsProvider returns the signing secrets currently accepted, e.g. from a secret manager.
+// While a secret is rotated both the old and the new one are active.
+type WebhookSecretsProvider interface {
+	WebhookSecrets(ctx context.Context) ([]string, error)
+}
+
+// WebhookSecrets is a fixed list of active signing secrets.
+type WebhookSecrets []string
+
+func (s WebhookSecrets) WebhookSecrets(ctx context.Context) ([]string, error) {
+	return s, nil
+}
+
+// WebhookSecretsFunc adapts an ordinary function to the WebhookSecretsProvider interface.
+type WebhookSecretsFunc func(ctx context.Context) ([]string, error)
+
+func (f WebhookSecretsFunc) WebhookSecrets(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// WebhookVerifier checks the signature of webhook calls against every active signing secret, so calls
+// signed with the old or the new secret both pass during a rotation. It is safe for concurrent use.
+type WebhookVerifier struct {
+	secrets WebhookSecretsProvider
+	// how old a call may be, DefaultWebhookTolerance when zero, unchecked when negative
+	Tolerance time.Duration
+	// the largest body VerifyRequest reads, DefaultWebhookMaxBody when zero
+	MaxBody int64
+}
+
+func NewWebhookVerifier(secrets WebhookSecretsProvider) *WebhookVerifier {
+	return &WebhookVerifier{secrets: secrets}
+}
+
+// Verify checks the raw body of a webhook call against the signature and timestamp headers. Empty secrets are
+// ignored, and without any other secret every call fails with ErrWebhookNoSecret.
+func (v *WebhookVerifier) Verify(ctx context.Context, header http.Header, body []byte) error {
+	timestamp := header.Get(WebhookTimestampHeader)
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid %s %q", WebhookTimestampHeader, timestamp)
+	}
+	if tolerance := v.tolerance(); tolerance > 0 {
+		if age := time.Since(time.UnixMilli(ms)); age > tolerance || age < -tolerance {
+			return fmt.Errorf("webhook: call timestamped %s ago is outside the tolerance of %s", age, tolerance)
+		}
+	}
+
+	secrets, err := v.secrets.WebhookSecrets(ctx)
+	if err != nil {
+		return fmt.Errorf("webhook: secrets: %w", err)
+	}
+
+	active := 0
+	signatures := strings.Split(header.Get(WebhookSignatureHeader), ",")
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		active++
+		expected := signWebhook(secret, timestamp, body)
+		for _, s := range signatures {
+			if hmac.Equal([]byte(strings.TrimSpace(s)), []byte(expected)) {
+				return nil
+			}
+		}
+	}
+	if active == 0 {
+		return ErrWebhookNoSecret
+	}
+	return ErrWebhookSignature
+}
+
+// VerifyRequest reads and verifies the body of a webhook call, and returns it. The body of the request
+// can still be read afterwards. A body larger than MaxBody fails with ErrWebhookBodyTooLarge.
+func (v *WebhookVerifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	limit := v.MaxBody
+	if limit <= 0 {
+		limit = DefaultWebhookMaxBody
+	}
+	b, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, limit))
+	r.Body.Close()
+	if err != nil {
+		if int64(len(b)) >= limit {
+			return nil, ErrWebhookBodyTooLarge
+		}
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	if err := v.Verify(r.Context(), r.Header, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v *WebhookVerifier) tolerance() time.Duration {
+	if v.Tolerance == 0 {
+		return DefaultWebhookTolerance
+	}
+	return v.Tolerance
+}
+
+// signWebhook returns the signature of a webhook call: the HMAC-SHA256 of "v1.<timestamp>.<body>"
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(webhookSignatureVersion + "." + timestamp + "."))
+	mac.Write(body)
+	return webhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
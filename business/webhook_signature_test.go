@@ -0,0 +1,76 @@
+package business_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v1." + timestamp + "."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookHeader(secret string, body []byte) http.Header {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	return http.Header{
+		business.WebhookTimestampHeader: {timestamp},
+		business.WebhookSignatureHeader: {sign(secret, timestamp, body)},
+	}
+}
+
+func TestWebhookVerify(t *testing.T) {
+	body := []byte(`{"event":"TransactionCreated"}`)
+	tests := []struct {
+		name     string
+		secrets  business.WebhookSecrets
+		signedBy string
+		want     error
+	}{
+		{"active secret", business.WebhookSecrets{"old", "new"}, "new", nil},
+		{"unknown secret", business.WebhookSecrets{"old", "new"}, "other", business.ErrWebhookSignature},
+		{"empty secret skipped", business.WebhookSecrets{"", "new"}, "", business.ErrWebhookSignature},
+		{"rotation with an unset secret", business.WebhookSecrets{"", "new"}, "new", nil},
+		{"only empty secrets", business.WebhookSecrets{""}, "", business.ErrWebhookNoSecret},
+		{"no secrets", nil, "", business.ErrWebhookNoSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := business.NewWebhookVerifier(tt.secrets)
+			if err := v.Verify(context.Background(), webhookHeader(tt.signedBy, body), body); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookVerifyRequestBodyLimit(t *testing.T) {
+	v := business.NewWebhookVerifier(business.WebhookSecrets{"secret"})
+	v.MaxBody = 16
+
+	small := []byte(`{}`)
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(small))
+	r.Header = webhookHeader("secret", small)
+	if b, err := v.VerifyRequest(r); err != nil || !bytes.Equal(b, small) {
+		t.Errorf("got %q, %v", b, err)
+	}
+
+	large := bytes.Repeat([]byte("x"), 17)
+	r = httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(large))
+	r.Header = webhookHeader("secret", large)
+	if _, err := v.VerifyRequest(r); !errors.Is(err, business.ErrWebhookBodyTooLarge) {
+		t.Errorf("got %v, want %v", err, business.ErrWebhookBodyTooLarge)
+	}
+}
@@ -0,0 +1,226 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	WebhookEventName_TRANSACTION_CREATED       = "TransactionCreated"
+	WebhookEventName_TRANSACTION_STATE_CHANGED = "TransactionStateChanged"
+)
+
+// WebhookEvent is a verified webhook call, decoded up to its data.
+type WebhookEvent struct {
+	// the event name, e.g. WebhookEventName_TRANSACTION_CREATED
+	Event string `json:"event"`
+	// the event time
+	Timestamp Time            `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Key identifies the event across redeliveries, to deduplicate them: a transaction is created once and
+// enters each state once, so events synthesised by Backfill share the key of the webhook call they replace.
+func (e *WebhookEvent) Key() string {
+	var data struct {
+		Id       string       `json:"id"`
+		NewState PaymentState `json:"new_state"`
+	}
+	json.Unmarshal(e.Data, &data)
+
+	switch e.Event {
+	case WebhookEventName_TRANSACTION_CREATED:
+		return e.Event + ":" + data.Id
+	case WebhookEventName_TRANSACTION_STATE_CHANGED:
+		return e.Event + ":" + data.Id + ":" + string(data.NewState)
+	}
+	return e.Event + ":" + data.Id + ":" + e.Timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+// TransactionCreated decodes a WebhookEventName_TRANSACTION_CREATED event.
+func (e *WebhookEvent) TransactionCreated() (*TransactionCreatedEvent, error) {
+	r := &TransactionCreatedEvent{Event: e.Event, Timestamp: e.Timestamp}
+	if err := json.Unmarshal(e.Data, &r.Data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TransactionStateChanged decodes a WebhookEventName_TRANSACTION_STATE_CHANGED event.
+func (e *WebhookEvent) TransactionStateChanged() (*TransactionStateChangedEvent, error) {
+	r := &TransactionStateChangedEvent{Event: e.Event, Timestamp: e.Timestamp}
+	if err := json.Unmarshal(e.Data, &r.Data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// WebhookHandler processes an event. An error makes Revolut deliver the event again.
+type WebhookHandler func(ctx context.Context, e *WebhookEvent) error
+
+// WebhookDelivery is an event received on a channel of a WebhookSubscriber. The webhook call is answered
+// once Done is called, with an error making Revolut deliver the event again.
+type WebhookDelivery struct {
+	Event *WebhookEvent
+	done  chan error
+}
+
+// Done acknowledges the delivery, it must be called exactly once.
+func (d *WebhookDelivery) Done(err error) {
+	d.done <- err
+}
+
+// WebhookDedupeStore remembers the events processed, so redeliveries are skipped.
+type WebhookDedupeStore interface {
+	// Seen reports whether the event of the key was processed
+	Seen(ctx context.Context, key string) (bool, error)
+	// MarkSeen records the event of the key as processed
+	MarkSeen(ctx context.Context, key string) error
+}
+
+// MemoryDedupeStore is an in-memory WebhookDedupeStore of a single instance, forgetting events after a TTL.
+// It is safe for concurrent use.
+type MemoryDedupeStore struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryDedupeStore returns a store remembering events for ttl, which should exceed the redelivery
+// window of Revolut.
+func NewMemoryDedupeStore(ttl time.Duration) *MemoryDedupeStore {
+	return &MemoryDedupeStore{ttl: ttl, seen: map[string]time.Time{}}
+}
+
+func (s *MemoryDedupeStore) Seen(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.seen[key]
+	return ok && time.Since(at) < s.ttl, nil
+}
+
+func (s *MemoryDedupeStore) MarkSeen(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, at := range s.seen {
+		if now.Sub(at) >= s.ttl {
+			delete(s.seen, k)
+		}
+	}
+	s.seen[key] = now
+	return nil
+}
+
+// WebhookSubscriber is the http.Handler of the webhook URL. It verifies the calls and dispatches their events
+// to the callbacks and channels registered for the event name. Delivery is at least once: the call is only
+// acknowledged once every callback and channel consumer succeeded, otherwise Revolut delivers the event again,
+// and events already processed are skipped when a dedupe store is set. It is safe for concurrent use.
+type WebhookSubscriber struct {
+	verifier *WebhookVerifier
+	dedupe   WebhookDedupeStore
+
+	mu       sync.RWMutex
+	handlers map[string][]WebhookHandler
+}
+
+// NewWebhookSubscriber returns a subscriber verifying calls with the verifier. The dedupe store is optional.
+func NewWebhookSubscriber(verifier *WebhookVerifier, dedupe WebhookDedupeStore) *WebhookSubscriber {
+	return &WebhookSubscriber{verifier: verifier, dedupe: dedupe, handlers: map[string][]WebhookHandler{}}
+}
+
+// Handle registers a callback for events with the given name, or for all events when the name is empty.
+// Callbacks of an event run one after the other on the goroutine of the webhook call.
+func (s *WebhookSubscriber) Handle(name string, handler WebhookHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[name] = append(s.handlers[name], handler)
+}
+
+// Channel returns a channel receiving the events with the given name, or all events when the name is empty.
+// Every delivery must be acknowledged with Done; the webhook call waits for it.
+func (s *WebhookSubscriber) Channel(name string, size int) <-chan *WebhookDelivery {
+	ch := make(chan *WebhookDelivery, size)
+	s.Handle(name, func(ctx context.Context, e *WebhookEvent) error {
+		d := &WebhookDelivery{Event: e, done: make(chan error, 1)}
+		select {
+		case ch <- d:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		select {
+		case err := <-d.done:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return ch
+}
+
+func (s *WebhookSubscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := s.verifier.VerifyRequest(r)
+	if errors.Is(err, ErrWebhookBodyTooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	e := &WebhookEvent{}
+	if err := json.Unmarshal(body, e); err != nil {
+		http.Error(w, "webhook: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Dispatch(r.Context(), e); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dispatch hands a verified event to its callbacks and channels, unless the dedupe store saw it already,
+// and records it as processed once they all succeeded.
+func (s *WebhookSubscriber) Dispatch(ctx context.Context, e *WebhookEvent) error {
+	key := e.Key()
+	if s.dedupe != nil {
+		seen, err := s.dedupe.Seen(ctx, key)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return nil
+		}
+	}
+
+	s.mu.RLock()
+	handlers := append(append([]WebhookHandler{}, s.handlers[e.Event]...), s.handlers[""]...)
+	s.mu.RUnlock()
+
+	// every handler runs, those which succeeded run again on the redelivery
+	var first error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil && first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return first
+	}
+
+	if s.dedupe != nil {
+		return s.dedupe.MarkSeen(ctx, key)
+	}
+	return nil
+}
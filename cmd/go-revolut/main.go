@@ -1,9 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
-	merchant "github.com/quiver-london/go-revolut/merchant/1.0"
+	"github.com/quiver-london/go-revolut/v2/merchant"
 )
 
 func main() {
@@ -145,7 +146,7 @@ func main() {
 
 	apiKey := "Yo1ysB60s8GHEFmrConnG450iK6diAw7cDf-wExzbMdqKi8buyWB5CSEETw6hI_Z"
 
-	order, err := merchant.NewClient(apiKey).Order().Create(&merchant.OrderReq{
+	order, err := merchant.NewClient(apiKey).Order().Create(context.Background(), &merchant.OrderReq{
 		Amount:             200,
 		CaptureMode:        merchant.CaptureMode_MANUAL,
 		MerchantOrderID:    "00122",
@@ -160,7 +161,7 @@ func main() {
 	}
 	fmt.Println(order)
 
-	webhooks, err := merchant.NewClient(apiKey).Webhook().List()
+	webhooks, err := merchant.NewClient(apiKey).Webhook().List(context.Background())
 	if err != nil {
 		panic(err)
 	}
@@ -170,7 +171,7 @@ func main() {
 
 	// Acme Corporation
 
-	err = merchant.NewClient(apiKey).Webhook().Set(&merchant.WebhookUrl{Url: "https://webhook.site/#!/9d886b86-2880-493f-abf9-2b0a04a77df5"})
+	err = merchant.NewClient(apiKey).Webhook().Set(context.Background(), &merchant.WebhookUrl{Url: "https://webhook.site/#!/9d886b86-2880-493f-abf9-2b0a04a77df5"})
 	if err != nil {
 		panic(err)
 	}
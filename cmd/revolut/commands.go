@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+	"github.com/quiver-london/go-revolut/v2/business/batch"
+	"github.com/quiver-london/go-revolut/v2/business/export"
+)
+
+func accountsList(args []string) error {
+	fs := flag.NewFlagSet("accounts list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	account, err := client.Account()
+	if err != nil {
+		return err
+	}
+	accounts, err := account.List(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCURRENCY\tBALANCE\tSTATE")
+	for _, a := range accounts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Id, a.Name, a.Currency, business.Currency(a.Currency).FormatAmount(a.Balance), a.State)
+	}
+	return w.Flush()
+}
+
+func exchangeRate(args []string) error {
+	fs := flag.NewFlagSet("exchange rate", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 || fs.NArg() > 3 {
+		return errUsage
+	}
+
+	req := &business.ExchangeRateReq{From: business.Currency(fs.Arg(0)), To: business.Currency(fs.Arg(1)), Amount: 1}
+	if fs.NArg() == 3 {
+		amount, err := strconv.ParseFloat(fs.Arg(2), 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount %q", fs.Arg(2))
+		}
+		req.Amount = amount
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	exchange, err := client.Exchange()
+	if err != nil {
+		return err
+	}
+	rate, err := exchange.Rate(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	format := func(a business.Amount) string {
+		return business.Currency(a.Currency).FormatAmount(a.Amount) + " " + a.Currency
+	}
+	fmt.Printf("%s = %s (rate %g, fee %s, %s)\n", format(rate.From), format(rate.To), rate.Rate, format(rate.Fee),
+		rate.RateDate.Format(time.RFC3339))
+	return nil
+}
+
+func pay(args []string) error {
+	fs := flag.NewFlagSet("pay", flag.ContinueOnError)
+	file := fs.String("file", "", "a JSON array of payment requests")
+	concurrency := fs.Int("concurrency", 4, "the number of payments executed at once")
+	prefix := fs.String("key-prefix", "", "the prefix of generated request IDs, reuse it to resume a batch safely")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("pay: --file is required")
+	}
+
+	b, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var reqs []*business.PaymentReq
+	if err := json.Unmarshal(b, &reqs); err != nil {
+		return fmt.Errorf("pay: %s: %w", *file, err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	report, err := batch.Payments(context.Background(), client, reqs, batch.Options{Concurrency: *concurrency, KeyPrefix: *prefix})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tREQUEST ID\tSTATUS\tTRANSACTION\tERROR")
+	for _, r := range report.Results {
+		id, msg := "", ""
+		if r.Transaction != nil {
+			id = r.Transaction.Id
+		}
+		if r.Err != nil {
+			msg = r.Err.Error()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.Index, r.Req.RequestId, r.Status, id, msg)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed := len(report.Failed()); failed > 0 {
+		return fmt.Errorf("%d of %d payments failed", failed, len(reqs))
+	}
+	return nil
+}
+
+func transactionsExport(args []string) error {
+	fs := flag.NewFlagSet("transactions export", flag.ContinueOnError)
+	from := fs.String("from", "", "the date or instant to export from")
+	to := fs.String("to", "", "the date or instant to export to, now by default")
+	format := fs.String("format", "csv", "csv, ofx or camt")
+	tz := fs.String("tz", "UTC", "the time zone of the exported dates")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return err
+	}
+	e := &export.Format{Location: loc}
+	var write func(w io.Writer, txs []*business.TransactionResp) error
+	switch *format {
+	case "csv":
+		write = e.CSV
+	case "ofx":
+		write = e.OFX
+	case "camt":
+		write = e.CAMT053
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	payments, err := client.Payment()
+	if err != nil {
+		return err
+	}
+	txs, err := payments.ListAll(context.Background(), &business.TransactionReq{From: *from, To: *to}).All()
+	if err != nil {
+		return err
+	}
+
+	return write(os.Stdout, txs)
+}
@@ -0,0 +1,73 @@
+// Command revolut is a command line client of the Revolut Business API, built on the SDK.
+//
+//	revolut accounts list
+//	revolut exchange rate GBP USD [amount]
+//	revolut pay --file payments.json
+//	revolut transactions export --from 2020-01-01 [--to 2020-02-01] [--format csv|ofx|camt] [--tz Europe/London]
+//
+// It is configured from the environment:
+//
+//	REVOLUT_CLIENT_ID      the client ID of the API certificate
+//	REVOLUT_REFRESH_TOKEN  the refresh token obtained on consent
+//	REVOLUT_PRIVATE_KEY    the path of the PEM private key, or the key itself
+//	REVOLUT_ISSUER         the JWT issuer, the redirect domain of the certificate
+//	REVOLUT_SANDBOX        "true" to use the sandbox
+//	REVOLUT_TOKEN_FILE     an optional file caching access tokens between runs
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/quiver-london/go-revolut/v2/business"
+)
+
+const usage = `usage:
+  revolut accounts list
+  revolut exchange rate FROM TO [AMOUNT]
+  revolut pay --file payments.json [--concurrency N] [--key-prefix PREFIX]
+  revolut transactions export --from DATE [--to DATE] [--format csv|ofx|camt] [--tz ZONE]
+`
+
+var errUsage = errors.New("unknown command")
+
+func main() {
+	err := run(os.Args[1:])
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "revolut:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) > 0 && args[0] == "pay" {
+		return pay(args[1:])
+	}
+	if len(args) < 2 {
+		return errUsage
+	}
+
+	switch args[0] + " " + args[1] {
+	case "accounts list":
+		return accountsList(args[2:])
+	case "exchange rate":
+		return exchangeRate(args[2:])
+	case "transactions export":
+		return transactionsExport(args[2:])
+	}
+	return errUsage
+}
+
+func newClient() (*business.Client, error) {
+	var opts []business.Option
+	if path := os.Getenv("REVOLUT_TOKEN_FILE"); path != "" {
+		opts = append(opts, business.WithTokenStore(business.NewFileTokenStore(path)))
+	}
+
+	return business.NewClientFromEnv(opts...)
+}
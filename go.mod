@@ -1,6 +1,9 @@
-module github.com/quiver-london/go-revolut
+module github.com/quiver-london/go-revolut/v2
 
+go 1.18
 
-go 1.14
-
-require github.com/dgrijalva/jwt-go v3.2.0+incompatible
+require (
+	github.com/golang-jwt/jwt/v5 v5.2.2
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
@@ -0,0 +1,249 @@
+// Command codegen generates, from a Revolut OpenAPI spec, a package with the request and response types of
+// every endpoint and a client with one basic method per endpoint. The client sends its calls through a Doer,
+// the business.Client in practice, so they get the token, rate limiting, retries, logging and tracing of the
+// hand-written services, which stay the ergonomic layer: the generated package is the complete, low-level one.
+// It is run with go generate from the business package:
+//
+//	REVOLUT_OPENAPI_SPEC=business.json go generate ./business
+//
+// Without a spec, REVOLUT_OPENAPI_SPEC unset, it skips generation and leaves the files as they are.
+//
+// Flags:
+//
+//	-spec    the OpenAPI 3 spec, in JSON
+//	-out     the generated file, generated/api.go by default
+//	-pkg     the name of the generated package, the directory of -out by default
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quiver-london/go-revolut/v2/internal/openapi"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "the OpenAPI 3 spec, in JSON")
+	out := flag.String("out", "generated/api.go", "the generated file")
+	pkg := flag.String("pkg", "", "the name of the generated package, the directory of -out by default")
+	flag.Parse()
+
+	if err := run(*specPath, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "codegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, out, pkg string) error {
+	// go generate passes an empty spec when REVOLUT_OPENAPI_SPEC is unset, the spec is not checked in
+	if specPath == "" {
+		fmt.Fprintln(os.Stderr, "codegen: no -spec, set REVOLUT_OPENAPI_SPEC to the Revolut OpenAPI spec; skipped")
+		return nil
+	}
+	if pkg == "" {
+		pkg = filepath.Base(filepath.Dir(out))
+	}
+	s, err := openapi.Load(specPath)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(s, pkg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+const header = `// Code generated by internal/cmd/codegen from the Revolut OpenAPI spec. DO NOT EDIT.
+
+// Package %s is the Revolut Business API as the OpenAPI spec describes it: a type for every request and
+// response, and a method for every endpoint, sent through the Do method of a business.Client.
+`
+
+const preamble = `
+// Doer sends a call to the API, the path relative to its root. *business.Client implements it.
+type Doer interface {
+	Do(ctx context.Context, method, path string, body, out interface{}) error
+}
+
+// Client calls the endpoints of the spec.
+type Client struct {
+	doer Doer
+}
+
+// New returns a client sending its calls through the doer.
+func New(doer Doer) *Client {
+	return &Client{doer: doer}
+}
+
+// setQuery adds a query parameter, unless its value is the zero value.
+func setQuery(query url.Values, key string, v interface{}) {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || rv.IsZero() {
+		return
+	}
+	if t, ok := v.(time.Time); ok {
+		query.Set(key, t.Format(time.RFC3339))
+		return
+	}
+	query.Set(key, fmt.Sprint(v))
+}
+
+// withQuery appends the encoded query to the path, when there is one.
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+`
+
+// generate returns the source of the package
+func generate(s *openapi.Spec, pkg string) ([]byte, error) {
+	w := openapi.NewTypeWriter(s)
+	for _, i := range []string{"context", "fmt", "net/url", "reflect", "time"} {
+		w.Import(i)
+	}
+
+	var code strings.Builder
+	code.WriteString(preamble)
+	for _, op := range s.Operations() {
+		method(w, &code, op)
+	}
+
+	return w.Source(fmt.Sprintf(header, pkg), pkg, code.String())
+}
+
+// method writes the types of an operation and the method calling it
+func method(w *openapi.TypeWriter, code *strings.Builder, op *openapi.Operation) {
+	name := openapi.TypeName(op)
+
+	args := []string{"ctx context.Context"}
+	used := map[string]bool{"ctx": true, "params": true, "req": true, "query": true, "resp": true}
+	pathArgs := map[string]string{}
+	var query []*openapi.Parameter
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			arg := argName(p.Name, used)
+			pathArgs[p.Name] = arg
+		case "query":
+			query = append(query, p)
+		}
+	}
+	// the path parameters are the first arguments, in the order of the path
+	for _, seg := range strings.Split(op.Path, "/") {
+		if p := pathParam(seg); p != "" {
+			if _, ok := pathArgs[p]; !ok {
+				pathArgs[p] = argName(p, used)
+			}
+			args = append(args, pathArgs[p]+" string")
+		}
+	}
+
+	if len(query) > 0 {
+		var sb strings.Builder
+		sb.WriteString("struct {\n")
+		for _, p := range query {
+			if p.Description != "" {
+				fmt.Fprintf(&sb, "// %s\n", strings.TrimSpace(strings.SplitN(p.Description, "\n", 2)[0]))
+			}
+			fmt.Fprintf(&sb, "%s %s\n", openapi.Exported(p.Name), w.GoType(name+"Params"+openapi.Exported(p.Name), p.Schema))
+		}
+		sb.WriteString("}")
+		fmt.Fprintf(code, "\n// %sParams are the query parameters of %s %s, a zero value left out.\ntype %sParams %s\n",
+			name, op.Method, op.Path, name, sb.String())
+		args = append(args, "params "+name+"Params")
+	}
+	if op.Request != nil {
+		w.Named(name+"Req", op.Request, fmt.Sprintf("%sReq is the body of %s %s.", name, op.Method, op.Path))
+		args = append(args, "req *"+name+"Req")
+	}
+	returns := "error"
+	if op.Response != nil {
+		w.Named(name+"Resp", op.Response, fmt.Sprintf("%sResp is the response of %s %s.", name, op.Method, op.Path))
+		returns = "(*" + name + "Resp, error)"
+	}
+
+	doc := strings.TrimSpace(op.Summary)
+	if doc == "" {
+		doc = op.Method + " " + op.Path
+	}
+	fmt.Fprintf(code, "\n// %s: %s\nfunc (c *Client) %s(%s) %s {\n", name, doc, name, strings.Join(args, ", "), returns)
+
+	path := pathExpr(op.Path, pathArgs)
+	if len(query) > 0 {
+		code.WriteString("query := url.Values{}\n")
+		for _, p := range query {
+			fmt.Fprintf(code, "setQuery(query, %q, params.%s)\n", p.Name, openapi.Exported(p.Name))
+		}
+		path = "withQuery(" + path + ", query)"
+	}
+	body := "nil"
+	if op.Request != nil {
+		body = "req"
+	}
+	if op.Response == nil {
+		fmt.Fprintf(code, "return c.doer.Do(ctx, %q, %s, %s, nil)\n}\n", op.Method, path, body)
+		return
+	}
+	fmt.Fprintf(code, "resp := &%sResp{}\nif err := c.doer.Do(ctx, %q, %s, %s, resp); err != nil {\nreturn nil, err\n}\nreturn resp, nil\n}\n",
+		name, op.Method, path, body)
+}
+
+// pathExpr returns the expression of the path relative to the API root, the parameters escaped
+func pathExpr(path string, args map[string]string) string {
+	path = strings.TrimPrefix(strings.Trim(path, "/"), "api/1.0/")
+	var parts []string
+	lit := ""
+	for i, seg := range strings.Split(path, "/") {
+		if i > 0 {
+			lit += "/"
+		}
+		if p := pathParam(seg); p != "" {
+			if lit != "" {
+				parts = append(parts, fmt.Sprintf("%q", lit))
+			}
+			parts = append(parts, "url.PathEscape("+args[p]+")")
+			lit = ""
+			continue
+		}
+		lit += seg
+	}
+	if lit != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", lit))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// pathParam returns the name of the parameter of a path segment, "" when the segment is fixed
+func pathParam(seg string) string {
+	if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return seg[1 : len(seg)-1]
+	}
+	return ""
+}
+
+// argName returns an unused argument name for a parameter, e.g. payment_id into paymentId
+func argName(param string, used map[string]bool) string {
+	arg := lowerFirst(openapi.Exported(param))
+	if token.IsKeyword(arg) || used[arg] {
+		arg += "Param"
+	}
+	used[arg] = true
+	return arg
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
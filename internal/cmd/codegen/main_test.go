@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// spec is the spec fixture shared by the tools
+const spec = "../../openapi/testdata/business.json"
+
+func TestGenerateGolden(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "api", "api.go")
+	if err := run(spec, out, ""); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "api.go.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("the generated code differs from %s, run go test -update after checking it:\n%s", golden, got)
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command to build the generated code")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module api\n\ngo 1.18\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(spec, filepath.Join(dir, "api.go"), "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(gobin, "vet", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("the generated code does not build: %v\n%s", err, out)
+	}
+}
+
+func TestRunWithoutSpec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "api", "api.go")
+	if err := run("", out, ""); err != nil {
+		t.Fatalf("got %v, want the generation skipped", err)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("got %s written without a spec", out)
+	}
+}
@@ -0,0 +1,147 @@
+// Command coverage diffs the endpoints the business package calls against a Revolut OpenAPI spec. It writes
+// a Markdown coverage table and, for the endpoints the package does not call, Go stub types generated from
+// their request and response schemas. It is run with go generate from the business package:
+//
+//	REVOLUT_OPENAPI_SPEC=business.json go generate ./business
+//
+// Without a spec, REVOLUT_OPENAPI_SPEC unset, it skips the coverage report and leaves the files as they are.
+//
+// Flags:
+//
+//	-spec    the OpenAPI 3 spec, in JSON
+//	-pkg     the directory of the package scanned for calls, . by default
+//	-out     the coverage table, COVERAGE.md by default
+//	-stubs   the stub file, none when empty
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/quiver-london/go-revolut/v2/internal/openapi"
+)
+
+// call is an endpoint the package calls
+type call struct {
+	Operation string
+	Method    string
+	// the path segments, "{}" for segments only known at run time
+	Segments []string
+	// where the call is made, file:line
+	Pos string
+}
+
+func main() {
+	specPath := flag.String("spec", "", "the OpenAPI 3 spec, in JSON")
+	pkg := flag.String("pkg", ".", "the directory of the package scanned for calls")
+	out := flag.String("out", "COVERAGE.md", "the coverage table")
+	stubs := flag.String("stubs", "", "the stub file, none when empty")
+	flag.Parse()
+
+	if err := run(*specPath, *pkg, *out, *stubs); err != nil {
+		fmt.Fprintln(os.Stderr, "coverage:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, pkg, out, stubs string) error {
+	// go generate passes an empty spec when REVOLUT_OPENAPI_SPEC is unset, the spec is not checked in
+	if specPath == "" {
+		fmt.Fprintln(os.Stderr, "coverage: no -spec, set REVOLUT_OPENAPI_SPEC to the Revolut OpenAPI spec; skipped")
+		return nil
+	}
+	s, err := openapi.Load(specPath)
+	if err != nil {
+		return err
+	}
+	calls, err := scan(pkg)
+	if err != nil {
+		return err
+	}
+
+	r := diff(s.Operations(), calls)
+	if err := os.WriteFile(out, []byte(r.markdown()), 0o644); err != nil {
+		return err
+	}
+	if stubs == "" {
+		return nil
+	}
+	src, err := generateStubs(s, r.missing())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stubs), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(stubs, src, 0o644)
+}
+
+// row is an operation of the spec and the calls implementing it
+type row struct {
+	op    *openapi.Operation
+	calls []call
+}
+
+type report struct {
+	rows []*row
+	// the calls matching no operation of the spec
+	unknown []call
+}
+
+// diff matches every call to the operations of the spec with the same method and path, a run time segment
+// of a call matching any segment, and a fixed one only the same fixed segment.
+func diff(ops []*openapi.Operation, calls []call) *report {
+	r := &report{}
+	for _, op := range ops {
+		r.rows = append(r.rows, &row{op: op})
+	}
+
+	for _, c := range calls {
+		matched := false
+		for _, row := range r.rows {
+			if c.matches(row.op) {
+				row.calls = append(row.calls, c)
+				matched = true
+			}
+		}
+		if !matched {
+			r.unknown = append(r.unknown, c)
+		}
+	}
+
+	sort.SliceStable(r.rows, func(i, j int) bool {
+		if r.rows[i].op.Path != r.rows[j].op.Path {
+			return r.rows[i].op.Path < r.rows[j].op.Path
+		}
+		return r.rows[i].op.Method < r.rows[j].op.Method
+	})
+	return r
+}
+
+func (c call) matches(op *openapi.Operation) bool {
+	if c.Method != "*" && c.Method != op.Method {
+		return false
+	}
+	if len(c.Segments) != len(op.Segments) {
+		return false
+	}
+	for i, s := range c.Segments {
+		if s != "{}" && s != op.Segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *report) missing() []*openapi.Operation {
+	var ops []*openapi.Operation
+	for _, row := range r.rows {
+		if len(row.calls) == 0 {
+			ops = append(ops, row.op)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quiver-london/go-revolut/v2/internal/openapi"
+)
+
+// spec is the spec fixture shared by the tools
+const spec = "../../openapi/testdata/business.json"
+
+func TestDiff(t *testing.T) {
+	s, err := openapi.Load(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := []call{
+		{Operation: "account.list", Method: "GET", Segments: []string{"accounts"}},
+		{Operation: "account.with_id", Method: "GET", Segments: []string{"accounts", "{}"}},
+		// a run time path segment matches any segment of the spec, a run time method any method
+		{Operation: "card.action", Method: "*", Segments: []string{"payout-links", "{}", "{}"}},
+		{Operation: "sandbox.topup", Method: "POST", Segments: []string{"sandbox", "topup"}},
+		// the method differs
+		{Operation: "account.delete", Method: "DELETE", Segments: []string{"accounts", "{}"}},
+	}
+
+	r := diff(s.Operations(), calls)
+	implemented := map[string]string{}
+	for _, row := range r.rows {
+		var names []string
+		for _, c := range row.calls {
+			names = append(names, c.Operation)
+		}
+		implemented[row.op.Method+" "+row.op.Path] = strings.Join(names, ",")
+	}
+	want := map[string]string{
+		"GET /accounts":              "account.list",
+		"GET /accounts/{account_id}": "account.with_id",
+		"POST /pay":                  "",
+		"POST /payout-links/{payout_link_id}/cancel": "card.action",
+		"GET /team-members":                          "",
+		"GET /transactions":                          "",
+	}
+	for op, names := range want {
+		if implemented[op] != names {
+			t.Errorf("%s: got implemented by %q, want %q", op, implemented[op], names)
+		}
+	}
+	if len(r.unknown) != 2 || r.unknown[0].Operation != "sandbox.topup" || r.unknown[1].Operation != "account.delete" {
+		t.Errorf("got unknown calls %+v, want sandbox.topup and account.delete", r.unknown)
+	}
+	if n := len(r.missing()); n != 3 {
+		t.Errorf("got %d missing operations, want 3", n)
+	}
+}
+
+func TestRunBusiness(t *testing.T) {
+	dir := t.TempDir()
+	out, stubs := filepath.Join(dir, "COVERAGE.md"), filepath.Join(dir, "stubs", "stubs.go")
+	if err := run(spec, "../../../business", out, stubs); err != nil {
+		t.Fatal(err)
+	}
+
+	md, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"| GET | `/accounts` | getAccounts | `account.list` |",
+		"| POST | `/pay` | createPayment | `payment.create` |",
+		"| GET | `/team-members` | getTeamMembers | — |",
+	} {
+		if !strings.Contains(string(md), line) {
+			t.Errorf("the coverage table misses %q:\n%s", line, md)
+		}
+	}
+
+	src, err := os.ReadFile(stubs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), "type GetTeamMembersResp ") || strings.Contains(string(src), "type CreatePaymentReq ") {
+		t.Errorf("got stubs for the wrong endpoints:\n%s", src)
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command to build the stubs")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stubs", "go.mod"), []byte("module stubs\n\ngo 1.18\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(gobin, "vet", ".")
+	cmd.Dir = filepath.Dir(stubs)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("the stubs do not build: %v\n%s", err, out)
+	}
+}
+
+func TestRunWithoutSpec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "COVERAGE.md")
+	if err := run("", "../../../business", out, ""); err != nil {
+		t.Fatalf("got %v, want the report skipped", err)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("got %s written without a spec", out)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markdown renders the coverage table, the operations sorted by path, then the calls the spec does not list.
+func (r *report) markdown() string {
+	covered := len(r.rows) - len(r.missing())
+
+	var sb strings.Builder
+	sb.WriteString("# Business API coverage\n\n")
+	sb.WriteString("Generated by internal/cmd/coverage from the Revolut OpenAPI spec, do not edit.\n\n")
+	if len(r.rows) > 0 {
+		fmt.Fprintf(&sb, "%d of %d endpoints implemented (%.0f%%).\n\n", covered, len(r.rows), 100*float64(covered)/float64(len(r.rows)))
+	}
+
+	sb.WriteString("| Method | Path | Operation ID | Implemented by |\n")
+	sb.WriteString("|---|---|---|---|\n")
+	for _, row := range r.rows {
+		by := "—"
+		if len(row.calls) > 0 {
+			names := make([]string, len(row.calls))
+			for i, c := range row.calls {
+				names[i] = c.name()
+			}
+			by = strings.Join(names, ", ")
+		}
+		fmt.Fprintf(&sb, "| %s | `%s` | %s | %s |\n", row.op.Method, row.op.Path, row.op.OperationId, by)
+	}
+
+	if len(r.unknown) > 0 {
+		sb.WriteString("\n## Calls missing from the spec\n\n")
+		sb.WriteString("| Method | Path | Called by |\n")
+		sb.WriteString("|---|---|---|\n")
+		for _, c := range r.unknown {
+			fmt.Fprintf(&sb, "| %s | `/%s` | %s |\n", c.Method, strings.Join(c.Segments, "/"), c.name())
+		}
+	}
+	return sb.String()
+}
+
+// name returns the operation of the call, or where it is made when the operation is only known at run time
+func (c call) name() string {
+	if c.Operation != "" {
+		return "`" + c.Operation + "`"
+	}
+	return c.Pos
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// httpMethods maps the method constants of net/http to their methods
+var httpMethods = map[string]string{
+	"MethodGet": "GET", "MethodHead": "HEAD", "MethodPost": "POST", "MethodPut": "PUT",
+	"MethodPatch": "PATCH", "MethodDelete": "DELETE", "MethodOptions": "OPTIONS",
+}
+
+// scan finds the request.Config literals of the package whose Url is built with endpoint. A method or a path
+// segment only known at run time is recorded as a wildcard.
+func scan(dir string) ([]call, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []call
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				lit, ok := n.(*ast.CompositeLit)
+				if !ok || !isSelector(lit.Type, "request", "Config") {
+					return true
+				}
+				if c, ok := callOf(lit); ok {
+					c.Pos = fset.Position(lit.Pos()).String()
+					calls = append(calls, c)
+				}
+				return true
+			})
+		}
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no request.Config built with endpoint in %s", dir)
+	}
+	return calls, nil
+}
+
+func callOf(lit *ast.CompositeLit) (call, bool) {
+	c := call{Method: "*"}
+	found := false
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, _ := kv.Key.(*ast.Ident)
+		if key == nil {
+			continue
+		}
+
+		switch key.Name {
+		case "Operation":
+			c.Operation, _ = stringOf(kv.Value)
+		case "Method":
+			if sel, ok := kv.Value.(*ast.SelectorExpr); ok && httpMethods[sel.Sel.Name] != "" {
+				c.Method = httpMethods[sel.Sel.Name]
+			} else if s, ok := stringOf(kv.Value); ok {
+				c.Method = strings.ToUpper(s)
+			}
+		case "Url":
+			fn, ok := kv.Value.(*ast.CallExpr)
+			if !ok || len(fn.Args) == 0 {
+				continue
+			}
+			if ident, ok := fn.Fun.(*ast.Ident); !ok || ident.Name != "endpoint" {
+				continue
+			}
+			// the first argument is the query
+			for _, arg := range fn.Args[1:] {
+				if s, ok := stringOf(arg); ok {
+					c.Segments = append(c.Segments, s)
+				} else {
+					c.Segments = append(c.Segments, "{}")
+				}
+			}
+			found = true
+		}
+	}
+	return c, found
+}
+
+func stringOf(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func isSelector(e ast.Expr, pkg, name string) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == pkg && sel.Sel.Name == name
+}
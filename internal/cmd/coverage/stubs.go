@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/quiver-london/go-revolut/v2/internal/openapi"
+)
+
+// generateStubs returns the source of a stubs package with the request and response types of the operations.
+func generateStubs(s *openapi.Spec, ops []*openapi.Operation) ([]byte, error) {
+	w := openapi.NewTypeWriter(s)
+	for _, op := range ops {
+		name := openapi.TypeName(op)
+		if op.Request != nil {
+			w.Named(name+"Req", op.Request, fmt.Sprintf("%s is the body of %s %s.", name+"Req", op.Method, op.Path))
+		}
+		if op.Response != nil {
+			w.Named(name+"Resp", op.Response, fmt.Sprintf("%s is the response of %s %s.", name+"Resp", op.Method, op.Path))
+		}
+	}
+
+	return w.Source(`// Code generated by internal/cmd/coverage from the Revolut OpenAPI spec. DO NOT EDIT.
+
+// Package stubs holds the types of the endpoints the business package does not call yet, a starting
+// point to implement them.
+`, "stubs", "")
+}
@@ -0,0 +1,184 @@
+// Package openapi reads the parts of an OpenAPI 3 document the repository tools need, and turns its schemas
+// into Go types.
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+)
+
+// the methods of an OpenAPI path item, in the order they are listed
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Spec is the part of an OpenAPI 3 document the tools read.
+type Spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is a JSON schema of the spec, or a reference to one of its components.
+type Schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Properties  map[string]*Schema `json:"properties"`
+	Required    []string           `json:"required"`
+	Items       *Schema            `json:"items"`
+	AllOf       []*Schema          `json:"allOf"`
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name string `json:"name"`
+	// path or query
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+type media struct {
+	Content map[string]struct {
+		Schema *Schema `json:"schema"`
+	} `json:"content"`
+}
+
+// Operation is an endpoint of the spec.
+type Operation struct {
+	Method string
+	Path   string
+	// the path segments, "{}" for the parameters
+	Segments    []string
+	OperationId string
+	Summary     string
+	// the path and query parameters, of the path item and of the operation
+	Parameters []*Parameter
+	// the JSON schemas of the request body and of the successful response, nil when none
+	Request  *Schema
+	Response *Schema
+}
+
+// Load reads a spec in JSON.
+func Load(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Spec{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Operations returns the operations of the spec, sorted by path then in the order of their methods.
+func (s *Spec) Operations() []*Operation {
+	paths := make([]string, 0, len(s.Paths))
+	for p := range s.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []*Operation
+	for _, p := range paths {
+		var shared []*Parameter
+		if raw, ok := s.Paths[p]["parameters"]; ok {
+			json.Unmarshal(raw, &shared)
+		}
+
+		for _, m := range methods {
+			raw, ok := s.Paths[p][m]
+			if !ok {
+				continue
+			}
+			var o struct {
+				OperationId string           `json:"operationId"`
+				Summary     string           `json:"summary"`
+				Parameters  []*Parameter     `json:"parameters"`
+				RequestBody media            `json:"requestBody"`
+				Responses   map[string]media `json:"responses"`
+			}
+			if err := json.Unmarshal(raw, &o); err != nil {
+				continue
+			}
+
+			op := &Operation{
+				Method:      strings.ToUpper(m),
+				Path:        p,
+				Segments:    segments(p),
+				OperationId: o.OperationId,
+				Summary:     o.Summary,
+				Parameters:  mergeParameters(shared, o.Parameters),
+				Request:     jsonSchema(o.RequestBody),
+			}
+			codes := make([]string, 0, len(o.Responses))
+			for code := range o.Responses {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				if strings.HasPrefix(code, "2") {
+					if op.Response = jsonSchema(o.Responses[code]); op.Response != nil {
+						break
+					}
+				}
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// Resolve follows the reference of a schema to the components, returning the name of the component.
+// The schema is nil when the reference is dangling.
+func (s *Spec) Resolve(sc *Schema) (string, *Schema) {
+	const prefix = "#/components/schemas/"
+	if sc == nil || !strings.HasPrefix(sc.Ref, prefix) {
+		return "", sc
+	}
+	name := strings.TrimPrefix(sc.Ref, prefix)
+	return name, s.Components.Schemas[name]
+}
+
+// mergeParameters returns the path and query parameters, those of the operation overriding those of the path item
+func mergeParameters(shared, own []*Parameter) []*Parameter {
+	var r []*Parameter
+	seen := map[string]bool{}
+	for _, list := range [][]*Parameter{own, shared} {
+		for _, p := range list {
+			if (p.In != "path" && p.In != "query") || seen[p.In+p.Name] {
+				continue
+			}
+			seen[p.In+p.Name] = true
+			r = append(r, p)
+		}
+	}
+	return r
+}
+
+func jsonSchema(m media) *Schema {
+	for typ, c := range m.Content {
+		if strings.Contains(typ, "json") {
+			return c.Schema
+		}
+	}
+	return nil
+}
+
+// segments splits a path of the spec, the API root and the parameters left out
+func segments(path string) []string {
+	path = strings.TrimPrefix(strings.Trim(path, "/"), "api/1.0/")
+	var r []string
+	for _, s := range strings.Split(path, "/") {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			s = "{}"
+		}
+		r = append(r, s)
+	}
+	return r
+}
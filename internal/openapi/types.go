@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TypeWriter generates the Go types of schemas, each named type once, and tracks the imports they need.
+type TypeWriter struct {
+	spec    *Spec
+	sb      strings.Builder
+	done    map[string]bool
+	imports map[string]bool
+}
+
+// NewTypeWriter returns a writer resolving the references of the schemas in the spec.
+func NewTypeWriter(s *Spec) *TypeWriter {
+	return &TypeWriter{spec: s, done: map[string]bool{}, imports: map[string]bool{}}
+}
+
+// Named writes a named type for the schema, once, after the types it refers to.
+func (w *TypeWriter) Named(name string, sc *Schema, doc string) {
+	if w.done[name] {
+		return
+	}
+	w.done[name] = true
+
+	typ := w.goType(name, sc)
+	fmt.Fprintf(&w.sb, "// %s\ntype %s %s\n\n", doc, name, typ)
+}
+
+// Import records an import the generated code needs.
+func (w *TypeWriter) Import(path string) {
+	w.imports[path] = true
+}
+
+// Source returns the formatted source of a file of the package holding the types written, after the header
+// and before the code given.
+func (w *TypeWriter) Source(header, pkg, code string) ([]byte, error) {
+	var src strings.Builder
+	src.WriteString(header)
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	if len(w.imports) > 0 {
+		var imports []string
+		for i := range w.imports {
+			imports = append(imports, fmt.Sprintf("%q", i))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&src, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	src.WriteString(w.sb.String())
+	src.WriteString(code)
+
+	b, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return b, nil
+}
+
+// GoType returns the Go type of a schema, writing the named types of nested objects and references.
+func (w *TypeWriter) GoType(name string, sc *Schema) string {
+	return w.fieldType(name, sc, "a "+name)
+}
+
+func (w *TypeWriter) goType(name string, sc *Schema) string {
+	if ref, resolved := w.spec.Resolve(sc); ref != "" {
+		if resolved == nil {
+			w.Import("encoding/json")
+			return "json.RawMessage"
+		}
+		ref = Exported(ref)
+		w.Named(ref, resolved, ref+" is a schema of the spec.")
+		return ref
+	}
+	if sc == nil {
+		w.Import("encoding/json")
+		return "json.RawMessage"
+	}
+
+	switch sc.Type {
+	case "string":
+		if sc.Format == "date-time" {
+			w.Import("time")
+			return "time.Time"
+		}
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		if sc.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + w.fieldType(name+"Item", sc.Items, "an item of "+name)
+	}
+
+	props := sc.Properties
+	required := map[string]bool{}
+	for _, r := range sc.Required {
+		required[r] = true
+	}
+	// allOf is merged into a single struct
+	for _, part := range sc.AllOf {
+		if _, p := w.spec.Resolve(part); p != nil {
+			if props == nil {
+				props = map[string]*Schema{}
+			}
+			for k, v := range p.Properties {
+				props[k] = v
+			}
+			for _, r := range p.Required {
+				required[r] = true
+			}
+		}
+	}
+	if len(props) == 0 {
+		if sc.Type == "object" {
+			return "map[string]interface{}"
+		}
+		w.Import("encoding/json")
+		return "json.RawMessage"
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	for _, k := range keys {
+		p := props[k]
+		field := Exported(k)
+		if p.Description != "" {
+			fmt.Fprintf(&sb, "// %s\n", firstLine(p.Description))
+		}
+		tag := k
+		if !required[k] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&sb, "%s %s `json:%q`\n", field, w.fieldType(name+field, p, "the "+k+" of "+name), tag)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// fieldType returns the Go type of a field or array item, a nested object getting a type of the given name
+func (w *TypeWriter) fieldType(name string, sc *Schema, what string) string {
+	if sc != nil && sc.Ref == "" && len(sc.Properties) > 0 {
+		w.Named(name, sc, fmt.Sprintf("%s is %s.", name, what))
+		return name
+	}
+	return w.goType(name, sc)
+}
+
+// TypeName names the types and methods of an operation after its ID, or its method and path.
+func TypeName(op *Operation) string {
+	if op.OperationId != "" {
+		return Exported(op.OperationId)
+	}
+	parts := []string{strings.ToLower(op.Method)}
+	for _, s := range op.Segments {
+		if s != "{}" {
+			parts = append(parts, s)
+		}
+	}
+	return Exported(strings.Join(parts, "_"))
+}
+
+// Exported turns a snake, kebab or camel case name into an exported Go identifier, e.g. created_at into CreatedAt.
+func Exported(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+func firstLine(s string) string {
+	return strings.TrimSpace(strings.SplitN(s, "\n", 2)[0])
+}
@@ -1,12 +1,13 @@
 package merchant
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 
-	"github.com/quiver-london/go-revolut/merchant/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/merchant/request"
 )
 
 type OrderService struct {
@@ -282,11 +283,12 @@ type RefundResp struct {
 
 // Create:
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-order-object-create-payment-order
-func (a *OrderService) Create(orderReq *OrderReq) (*OrderResp, error) {
+func (a *OrderService) Create(ctx context.Context, orderReq *OrderReq) (*OrderResp, error) {
 	resp, statusCode, err := request.New(request.Config{
 		Method:      http.MethodPost,
 		Url:         "https://merchant.revolut.com/api/1.0/orders",
 		ApiKey:      a.apiKey,
+		Context:     ctx,
 		Body:        orderReq,
 		ContentType: request.ContentType_APPLICATION_JSON,
 	})
@@ -308,11 +310,12 @@ func (a *OrderService) Create(orderReq *OrderReq) (*OrderResp, error) {
 
 // WithId: If you would like to get information about the created order, please use the following request.
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-order-object-retrieve-order
-func (a *OrderService) WithId(id string) (*OrderResp, error) {
+func (a *OrderService) WithId(ctx context.Context, id string) (*OrderResp, error) {
 	resp, statusCode, err := request.New(request.Config{
-		Method: http.MethodGet,
-		Url:    fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s", id),
-		ApiKey: a.apiKey,
+		Method:  http.MethodGet,
+		Url:     fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s", id),
+		ApiKey:  a.apiKey,
+		Context: ctx,
 	})
 	if err != nil {
 		return nil, err
@@ -333,11 +336,12 @@ func (a *OrderService) WithId(id string) (*OrderResp, error) {
 // Capture: Once the payment is authorised, the merchant needs to
 // capture it in order for it to be sent into the processing stage.
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-order-object-capture-order
-func (a *OrderService) Capture(id string) (*OrderResp, error) {
+func (a *OrderService) Capture(ctx context.Context, id string) (*OrderResp, error) {
 	resp, statusCode, err := request.New(request.Config{
-		Method: http.MethodPost,
-		Url:    fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s/capture", id),
-		ApiKey: a.apiKey,
+		Method:  http.MethodPost,
+		Url:     fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s/capture", id),
+		ApiKey:  a.apiKey,
+		Context: ctx,
 	})
 	if err != nil {
 		return nil, err
@@ -358,11 +362,12 @@ func (a *OrderService) Capture(id string) (*OrderResp, error) {
 // Cancel: In case the payment has not been captured yet and the merchant decides
 // to not proceed with the order, the order can be cancelled manually.
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-order-object-cancel-order
-func (a *OrderService) Cancel(id string) (*OrderResp, error) {
+func (a *OrderService) Cancel(ctx context.Context, id string) (*OrderResp, error) {
 	resp, statusCode, err := request.New(request.Config{
-		Method: http.MethodPost,
-		Url:    fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s/cancel", id),
-		ApiKey: a.apiKey,
+		Method:  http.MethodPost,
+		Url:     fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s/cancel", id),
+		ApiKey:  a.apiKey,
+		Context: ctx,
 	})
 	if err != nil {
 		return nil, err
@@ -383,11 +388,12 @@ func (a *OrderService) Cancel(id string) (*OrderResp, error) {
 // Refund: In case the customer requires a refund for a payment that has been already captured,
 // the merchant can always issue a full or partial refund for a particular payment.
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-order-object-refund-order
-func (a *OrderService) Refund(id string, refundReq *RefundReq) (*RefundResp, error) {
+func (a *OrderService) Refund(ctx context.Context, id string, refundReq *RefundReq) (*RefundResp, error) {
 	resp, statusCode, err := request.New(request.Config{
 		Method:      http.MethodPost,
 		Url:         fmt.Sprintf("https://merchant.revolut.com/api/1.0/orders/%s/refund", id),
 		ApiKey:      a.apiKey,
+		Context:     ctx,
 		Body:        refundReq,
 		ContentType: request.ContentType_APPLICATION_JSON,
 	})
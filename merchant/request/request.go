@@ -2,6 +2,7 @@ package request
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,6 +15,7 @@ type Config struct {
 	ApiKey      string
 	Body        interface{}
 	ContentType ContentType
+	Context     context.Context
 }
 
 type ContentType string
@@ -34,7 +36,11 @@ func New(conf Config) ([]byte, int, error) {
 			return []byte{}, 0, err
 		}
 	}
-	req, err := http.NewRequest(conf.Method, conf.Url, bytes.NewReader(b))
+	ctx := conf.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, conf.Method, conf.Url, bytes.NewReader(b))
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -1,11 +1,12 @@
 package merchant
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 
-	"github.com/quiver-london/go-revolut/merchant/1.0/request"
+	"github.com/quiver-london/go-revolut/v2/merchant/request"
 )
 
 type WebhookService struct {
@@ -24,12 +25,13 @@ type WebhookResp struct {
 
 // Set:
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-webhooks-set-or-revoke-webhook-url
-func (w *WebhookService) Set(webhookReq *WebhookUrl) error {
+func (w *WebhookService) Set(ctx context.Context, webhookReq *WebhookUrl) error {
 
 	resp, statusCode, err := request.New(request.Config{
 		Method:      http.MethodPost,
 		Url:         "https://merchant.revolut.com/api/1.0/webhooks",
 		ApiKey:      w.apiKey,
+		Context:     ctx,
 		Body:        webhookReq,
 		ContentType: request.ContentType_APPLICATION_JSON,
 	})
@@ -45,12 +47,13 @@ func (w *WebhookService) Set(webhookReq *WebhookUrl) error {
 
 // List:
 // doc: https://revolut-engineering.github.io/api-docs/merchant-api/#backend-api-backend-api-webhooks-retrieve-webhooks
-func (w *WebhookService) List() ([]*WebhookUrl, error) {
+func (w *WebhookService) List(ctx context.Context) ([]*WebhookUrl, error) {
 
 	resp, statusCode, err := request.New(request.Config{
-		Method: http.MethodGet,
-		Url:    "https://merchant.revolut.com/api/1.0/webhooks",
-		ApiKey: w.apiKey,
+		Method:  http.MethodGet,
+		Url:     "https://merchant.revolut.com/api/1.0/webhooks",
+		ApiKey:  w.apiKey,
+		Context: ctx,
 	})
 	if err != nil {
 		return nil, err